@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -10,9 +11,13 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/events"
+	"github.com/X-Keeper/geoborder/internal/eventsink"
 	"github.com/X-Keeper/geoborder/internal/geofence"
 	"github.com/X-Keeper/geoborder/internal/storage/geocache"
 	"github.com/X-Keeper/geoborder/internal/storage/postgres"
+	"github.com/X-Keeper/geoborder/internal/tracking"
+	"github.com/X-Keeper/geoborder/internal/webhook"
 	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
 	"github.com/X-Keeper/geoborder/pkg/logger"
 )
@@ -59,6 +64,39 @@ func main() {
 	done := make(chan bool)
 
 	dbUpdater(done, ticker, memoryGeoCache, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	webhooks, err := webhookSink(ctx, cfg)
+	if err != nil {
+		logger.LogError(errors.Wrap(err, "[MAIN] : error create webhook sink"), cfg.Log)
+		os.Exit(1)
+	}
+
+	outbox, sinkDone, err := eventSink(ctx, cfg)
+	if err != nil {
+		logger.LogError(errors.Wrap(err, "[MAIN] : error create event sink"), cfg.Log)
+		os.Exit(1)
+	}
+
+	// шина присваивает событиям номера, очереди webhook и получателя получают переходы до возврата из Track
+	bus := events.NewBus(cfg.EventBufferSize)
+	publishers := tracking.Publishers{bus}
+
+	if webhooks != nil {
+		publishers = append(publishers, webhooks)
+	}
+
+	if outbox != nil {
+		publishers = append(publishers, outbox)
+	}
+
+	tracker := tracking.NewTracker(memoryGeoCache, publishers, cfg.DeviceStateTTL, cfg.DwellTime)
+	stateTicker := time.NewTicker(time.Minute)
+	stateDone := make(chan bool)
+
+	stateExpirer(stateDone, stateTicker, tracker, cfg)
+
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCConfig.Port))
 	if err != nil {
 		logger.LogError(errors.Wrap(err, "[MAIN] : error listen tcp"), cfg.Log)
@@ -69,9 +107,10 @@ func main() {
 
 	server := grpc.NewServer()
 
-	geoborderServer := geofence.NewGeoborderServer(memoryGeoCache)
+	geoborderServer := geofence.NewGeoborderServer(memoryGeoCache, tracker, bus, cfg)
 
 	gf.RegisterGeofenceServiceServer(server, geoborderServer)
+	gf.RegisterGeofenceAdminServiceServer(server, geofence.NewAdminServer(memoryGeoCache, webhooks))
 
 	if err := server.Serve(listener); err != nil {
 		logger.LogError(errors.Wrap(err, "[MAIN] : error start server"), cfg.Log)
@@ -79,6 +118,10 @@ func main() {
 	}
 	ticker.Stop()
 	done <- true
+	stateTicker.Stop()
+	stateDone <- true
+	cancel()
+	<-sinkDone
 }
 
 func dbUpdater(done chan bool, ticker *time.Ticker, memoryGeoCache *geocache.MemoryGeoCache, cfg *config.Config) {
@@ -98,3 +141,82 @@ func dbUpdater(done chan bool, ticker *time.Ticker, memoryGeoCache *geocache.Mem
 		}
 	}()
 }
+
+// stateExpirer - периодическое удаление состояния устройств, от которых давно не было точек.
+func stateExpirer(done chan bool, ticker *time.Ticker, tracker *tracking.Tracker, cfg *config.Config) {
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if count := tracker.Expire(now); count > 0 {
+					logger.LogDebug(fmt.Sprintf("[MAIN]::stateExpirer : expired %d devices", count), cfg.Log)
+				}
+			}
+		}
+	}()
+}
+
+// webhookSink - доставка переходов на webhook пользователей, nil - адреса webhook не заданы.
+func webhookSink(ctx context.Context, cfg *config.Config) (*webhook.Sink, error) {
+	if cfg.WebhooksFile == "" {
+		return nil, nil
+	}
+
+	endpoints, err := webhook.LoadEndpoints(cfg.WebhooksFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sink, err := webhook.NewSink(endpoints, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	go sink.Run(ctx)
+
+	return sink, nil
+}
+
+// eventSink - передача переходов получателю cfg.EventSink через исходящую очередь, nil - получатель не задан.
+// Канал закрывается после передачи накопленного пакета и закрытия получателя и очереди.
+func eventSink(ctx context.Context, cfg *config.Config) (*eventsink.Outbox, <-chan struct{}, error) {
+	done := make(chan struct{})
+
+	if cfg.EventSink == "" {
+		close(done)
+
+		return nil, done, nil
+	}
+
+	sink, err := eventsink.New(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outbox, err := eventsink.NewOutbox(cfg.EventSinkQueueFile, cfg.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batcher := eventsink.NewBatcher(sink, outbox, cfg)
+
+	go func() {
+		defer close(done)
+
+		if err := batcher.Run(ctx); err != nil {
+			logger.LogError(errors.Wrap(err, "[MAIN]::eventSink : run batcher"), cfg.Log)
+		}
+
+		if err := sink.Close(); err != nil {
+			logger.LogError(errors.Wrap(err, "[MAIN]::eventSink : close sink"), cfg.Log)
+		}
+
+		if err := outbox.Close(); err != nil {
+			logger.LogError(errors.Wrap(err, "[MAIN]::eventSink : close queue"), cfg.Log)
+		}
+	}()
+
+	return outbox, done, nil
+}
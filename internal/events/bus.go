@@ -0,0 +1,134 @@
+// Package events - шина переходов устройств относительно геозон для подписчиков.
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// watchBatch - количество событий, читаемых подписчиком из буфера за один раз.
+const watchBatch = 100
+
+// Bus - шина событий. Последние события хранятся в кольцевом буфере, каждому событию присваивается
+// возрастающий номер, по которому подписчик продолжает чтение после переподключения. Подписчики читают
+// буфер независимо друг от друга, поэтому медленный подписчик не задерживает публикацию.
+type Bus struct {
+	sync.Mutex
+
+	ring []models.Event
+	// номер следующего события, нумерация с 1
+	next uint64
+	// закрывается при публикации новых событий
+	published chan struct{}
+}
+
+// NewBus - size - количество последних событий, доступных для продолжения чтения.
+func NewBus(size int) *Bus {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Bus{
+		ring:      make([]models.Event, size),
+		next:      1,
+		published: make(chan struct{}),
+	}
+}
+
+// Publish - присваивает событиям номера и сохраняет их в буфере, вытесняя самые старые.
+func (b *Bus) Publish(events ...models.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	for i := range events {
+		events[i].Sequence = b.next
+		b.ring[b.next%uint64(len(b.ring))] = events[i]
+		b.next++
+	}
+
+	close(b.published)
+	b.published = make(chan struct{})
+}
+
+// Next - номер, который получит следующее опубликованное событие.
+func (b *Bus) Next() uint64 {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.next
+}
+
+// Watch - передает в send события, удовлетворяющие фильтру, начиная с номера from, пока не завершится ctx
+// или send не вернет ошибку. from = 0 - только события, опубликованные после подписки. Если события
+// с номером from уже вытеснены из буфера, чтение начинается с самого старого сохраненного события:
+// пропуск подписчик определяет по номерам событий.
+func (b *Bus) Watch(ctx context.Context, from uint64, filter *models.EventFilter, send func(models.Event) error) error {
+	if from == 0 {
+		from = b.Next()
+	}
+
+	for {
+		var (
+			events    []models.Event
+			published <-chan struct{}
+		)
+
+		events, from, published = b.read(from, watchBatch)
+
+		for i := range events {
+			if !filter.Match(&events[i]) {
+				continue
+			}
+
+			if err := send(events[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(events) > 0 {
+			continue
+		}
+
+		select {
+		case <-published:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// read - не больше limit событий с номерами от from, номер, с которого продолжить чтение, и канал, который
+// закроется при публикации новых событий. Номер from больше следующего (например, после перезапуска сервиса)
+// читается с первого нового события.
+func (b *Bus) read(from uint64, limit int) ([]models.Event, uint64, <-chan struct{}) {
+	b.Lock()
+	defer b.Unlock()
+
+	size := uint64(len(b.ring))
+
+	if oldest := b.next - size; b.next > size && from < oldest {
+		from = oldest
+	}
+
+	if from == 0 || from > b.next {
+		from = b.next
+	}
+
+	count := b.next - from
+	if count > uint64(limit) {
+		count = uint64(limit)
+	}
+
+	events := make([]models.Event, 0, count)
+	for seq := from; seq < from+count; seq++ {
+		events = append(events, b.ring[seq%size])
+	}
+
+	return events, from + count, b.published
+}
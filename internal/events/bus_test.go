@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// collect - номера событий, полученных подписчиком до отмены после count событий или по таймауту.
+func collect(t *testing.T, bus *Bus, from uint64, filter *models.EventFilter, count int) []uint64 {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var got []uint64
+
+	_ = bus.Watch(ctx, from, filter, func(e models.Event) error {
+		got = append(got, e.Sequence)
+		if len(got) == count {
+			cancel()
+		}
+
+		return nil
+	})
+
+	return got
+}
+
+func publish(bus *Bus, count int) {
+	for i := 0; i < count; i++ {
+		bus.Publish(models.Event{DeviceID: uint64(i % 2), GeofenceID: 1, Type: models.EventEnter})
+	}
+}
+
+func TestBusWatchResume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		size   int
+		from   uint64
+		filter *models.EventFilter
+		want   []uint64
+	}{
+		{
+			name: "resume from sequence",
+			size: 10,
+			from: 3,
+			want: []uint64{3, 4, 5},
+		},
+		{
+			name: "evicted events start from oldest",
+			size: 3,
+			from: 1,
+			want: []uint64{3, 4, 5},
+		},
+		{
+			name: "sequence after restart reads new events",
+			size: 10,
+			from: 100,
+			want: []uint64{6},
+		},
+		{
+			name:   "filter by device",
+			size:   10,
+			from:   1,
+			filter: &models.EventFilter{DeviceIDs: []uint64{1}},
+			want:   []uint64{2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bus := NewBus(tt.size)
+			publish(bus, 5)
+
+			done := make(chan []uint64)
+			go func() { done <- collect(t, bus, tt.from, tt.filter, len(tt.want)) }()
+
+			// новое событие, которое подписчик получает после сохраненных
+			time.Sleep(50 * time.Millisecond)
+			publish(bus, 1)
+
+			got := <-done
+			if len(got) != len(tt.want) {
+				t.Fatalf("Watch() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Watch() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBusWatchNewOnly(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus(10)
+	publish(bus, 3)
+
+	done := make(chan []uint64)
+	go func() { done <- collect(t, bus, 0, nil, 2) }()
+
+	time.Sleep(50 * time.Millisecond)
+	publish(bus, 2)
+
+	if got := <-done; len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Errorf("Watch() = %v, want [4 5]", got)
+	}
+}
@@ -0,0 +1,139 @@
+package eventsink
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/pkg/logger"
+)
+
+const (
+	// defaultRetryDelay - задержка перед первой повторной передачей пакета, каждая следующая удваивается.
+	defaultRetryDelay = time.Second
+	// maxRetryDelay - наибольшая задержка между повторными передачами пакета.
+	maxRetryDelay = time.Minute
+	// closeTimeout - время на передачу накопленного пакета при остановке.
+	closeTimeout = 5 * time.Second
+)
+
+// Batcher - передача событий исходящей очереди получателю пакетами: пакет отправляется, когда набрано
+// batchSize событий или прошло flushInterval с предыдущей отправки. Пакет, который получатель не принял,
+// передается повторно, пока не будет принят, следующие события в это время ждут в очереди. Принятый пакет
+// подтверждается в очереди, поэтому после перезапуска передача продолжается с первого неподтвержденного события.
+type Batcher struct {
+	sink          storage.EventSink
+	outbox        *Outbox
+	batchSize     int
+	flushInterval time.Duration
+	retryDelay    time.Duration
+	log           *logger.Logger
+}
+
+func NewBatcher(sink storage.EventSink, outbox *Outbox, cfg *config.Config) *Batcher {
+	batchSize := cfg.EventSinkBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	flushInterval := cfg.EventSinkFlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	return &Batcher{
+		sink:          sink,
+		outbox:        outbox,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		retryDelay:    defaultRetryDelay,
+		log:           cfg.Log,
+	}
+}
+
+// Run - передача событий очереди до завершения ctx. При остановке накопленный пакет передается одной попыткой,
+// непереданные события остаются в очереди.
+func (b *Batcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		batch, published := b.outbox.Pending(b.batchSize)
+
+		if len(batch) < b.batchSize {
+			select {
+			case <-published:
+				continue
+			case <-ticker.C:
+				if len(batch) == 0 {
+					continue
+				}
+			case <-ctx.Done():
+				return b.close(batch)
+			}
+		}
+
+		if err := b.flush(ctx, batch); err != nil {
+			return b.close(batch)
+		}
+
+		b.ack(batch)
+	}
+}
+
+// flush - передача пакета с повторами до успеха или завершения ctx.
+func (b *Batcher) flush(ctx context.Context, batch []models.Event) error {
+	delay := b.retryDelay
+
+	for {
+		err := b.sink.Write(ctx, batch)
+		if err == nil {
+			return nil
+		}
+
+		logger.LogError(errors.Wrapf(err, "[EVENT_SINK]::flush : write %d events", len(batch)), b.log)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// close - передача накопленного пакета при остановке.
+func (b *Batcher) close(batch []models.Event) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	if err := b.sink.Write(ctx, batch); err != nil {
+		return errors.Wrapf(err, "write %d events on close", len(batch))
+	}
+
+	b.ack(batch)
+
+	return nil
+}
+
+// ack - подтверждение принятого пакета в очереди.
+func (b *Batcher) ack(batch []models.Event) {
+	if err := b.outbox.Ack(batch[len(batch)-1].Sequence); err != nil {
+		logger.LogError(errors.Wrap(err, "[EVENT_SINK]::ack"), b.log)
+	}
+}
@@ -0,0 +1,164 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// flakySink - получатель, который отклоняет первые failures пакетов.
+type flakySink struct {
+	sync.Mutex
+	failures int
+	batches  [][]uint64
+}
+
+func (s *flakySink) Write(_ context.Context, batch []models.Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+
+		return errors.New("unavailable")
+	}
+
+	sequences := make([]uint64, 0, len(batch))
+	for i := range batch {
+		sequences = append(sequences, batch[i].Sequence)
+	}
+
+	s.batches = append(s.batches, sequences)
+
+	return nil
+}
+
+func (s *flakySink) Close() error {
+	return nil
+}
+
+func (s *flakySink) delivered() [][]uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return append([][]uint64(nil), s.batches...)
+}
+
+func TestBatcherRun(t *testing.T) {
+	t.Parallel()
+
+	outbox, err := NewOutbox("", nil)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	sink := &flakySink{failures: 1}
+
+	batcher := NewBatcher(sink, outbox,
+		&config.Config{EventSinkBatchSize: 3, EventSinkFlushInterval: 50 * time.Millisecond})
+	batcher.retryDelay = 10 * time.Millisecond
+
+	// события, поставленные в очередь до запуска, тоже передаются
+	outbox.Publish(models.Event{DeviceID: 1, Type: models.EventEnter})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- batcher.Run(ctx) }()
+
+	for i := 2; i <= 4; i++ {
+		outbox.Publish(models.Event{DeviceID: uint64(i), Type: models.EventEnter})
+	}
+
+	// полный пакет передается повторно после ошибки, неполный - по истечении flushInterval
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sink.delivered()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// накопленный пакет передается при остановке
+	outbox.Publish(models.Event{DeviceID: 9, Type: models.EventExit})
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err = <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := [][]uint64{{1, 2, 3}, {4}, {5}}
+	got := sink.delivered()
+
+	if len(got) != len(want) {
+		t.Fatalf("batches = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if len(got[i]) != len(want[i]) || got[i][0] != want[i][0] {
+			t.Errorf("batches = %v, want %v", got, want)
+		}
+	}
+
+	if pending, _ := outbox.Pending(10); len(pending) != 0 {
+		t.Errorf("Pending() = %v, want all events acknowledged", pending)
+	}
+}
+
+func TestBatcherRestart(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+
+	outbox, err := NewOutbox(path, nil)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	// получатель недоступен до остановки сервиса: события не подтверждены и передаются после перезапуска
+	batcher := NewBatcher(&flakySink{failures: 100}, outbox, &config.Config{EventSinkBatchSize: 2})
+	batcher.retryDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- batcher.Run(ctx) }()
+
+	for i := 0; i < 3; i++ {
+		outbox.Publish(models.Event{DeviceID: uint64(i), Type: models.EventEnter})
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	if err = <-done; err == nil {
+		t.Error("Run() error = nil, want unaccepted batch")
+	}
+
+	_ = outbox.Close()
+
+	if outbox, err = NewOutbox(path, nil); err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	sink := &flakySink{}
+	batcher = NewBatcher(sink, outbox, &config.Config{EventSinkBatchSize: 3})
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { done <- batcher.Run(ctx) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(sink.delivered()) < 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := sink.delivered(); len(got) != 1 || len(got[0]) != 3 || got[0][0] != 1 || got[0][2] != 3 {
+		t.Errorf("batches = %v, want [[1 2 3]]", got)
+	}
+}
@@ -0,0 +1,164 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// Stdout - путь получателя FileSink для стандартного вывода.
+const Stdout = "stdout"
+
+// FileSink - запись переходов в файл или стандартный вывод, по одному JSON-объекту EventMessage в строке.
+// Пакет записывается одним вызовом и считается принятым после сброса на диск. После ошибки записи файл
+// открывается заново и обрезается до конца последнего принятого пакета, поэтому неполные строки
+// не остаются в файле.
+type FileSink struct {
+	sync.Mutex
+
+	// path - путь файла, пустая строка - стандартный вывод
+	path string
+	file *os.File
+	// size - размер файла после последнего принятого пакета
+	size int64
+	// broken - последняя запись завершилась ошибкой, файл нужно открыть заново
+	broken bool
+}
+
+// NewFileSink - path - файл, в конец которого дописываются события, Stdout - стандартный вывод.
+func NewFileSink(path string) (*FileSink, error) {
+	if path == Stdout {
+		return &FileSink{file: os.Stdout}, nil
+	}
+
+	file, size, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{path: path, file: file, size: size}, nil
+}
+
+func (s *FileSink) Write(_ context.Context, events []models.Event) error {
+	var buf bytes.Buffer
+	if err := writeLines(&buf, events); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.path == "" {
+		_, err := s.file.Write(buf.Bytes())
+
+		return errors.Wrap(err, "write events")
+	}
+
+	if err := s.write(buf.Bytes()); err != nil {
+		s.broken = true
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.path == "" || s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return errors.Wrap(err, "close event file")
+}
+
+// write - запись пакета data со сбросом на диск, после ошибки предыдущей записи - в заново открытый файл.
+func (s *FileSink) write(data []byte) error {
+	if s.broken {
+		if err := s.reopen(); err != nil {
+			return err
+		}
+
+		s.broken = false
+	}
+
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Wrap(err, "write events")
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return errors.Wrap(err, "sync events")
+	}
+
+	s.size += int64(len(data))
+
+	return nil
+}
+
+// reopen - открывает файл заново. Данные после конца последнего принятого пакета - неполный пакет,
+// запись которого завершилась ошибкой, - отбрасываются.
+func (s *FileSink) reopen() error {
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+
+	file, size, err := openAppend(s.path)
+	if err != nil {
+		return err
+	}
+
+	if size < s.size {
+		// файл заменен другим, например при ротации
+		s.size = size
+	} else if err = file.Truncate(s.size); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "truncate event file")
+	}
+
+	s.file = file
+
+	return nil
+}
+
+// openAppend - открывает файл для дописывания, возвращает его размер.
+func openAppend(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644) // nolint:gomnd // rw-r--r--
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "open event file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, 0, errors.Wrap(err, "stat event file")
+	}
+
+	return file, info.Size(), nil
+}
+
+func writeLines(w io.Writer, events []models.Event) error {
+	encoder := json.NewEncoder(w)
+
+	for i := range events {
+		if err := encoder.Encode(models.NewEventMessage(events[i])); err != nil {
+			return errors.Wrap(err, "encode event")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+func TestFileSinkWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	// события дописываются в конец файла после переоткрытия
+	for _, seq := range []uint64{1, 2} {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatalf("NewFileSink() error = %v", err)
+		}
+
+		if err = sink.Write(context.Background(), []models.Event{{Sequence: seq, DeviceID: 7}}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+
+		if err = sink.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer file.Close()
+
+	var got []uint64
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var m models.EventMessage
+		if err = json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+
+		got = append(got, m.Sequence)
+	}
+
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("sequences = %v, want [1 2]", got)
+	}
+}
+
+func TestFileSinkRecover(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+
+	defer sink.Close()
+
+	if err = sink.Write(context.Background(), []models.Event{{Sequence: 1}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// неполная строка пакета, запись которого завершилась ошибкой
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	_, _ = file.WriteString(`{"sequence":2,"dev`)
+	_ = file.Close()
+
+	sink.broken = true
+
+	if err = sink.Write(context.Background(), []models.Event{{Sequence: 2}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q, want 2 lines", lines)
+	}
+
+	for i, line := range lines {
+		var m models.EventMessage
+		if err = json.Unmarshal([]byte(line), &m); err != nil || m.Sequence != uint64(i+1) {
+			t.Errorf("line %d = %q, want event %d", i, line, i+1)
+		}
+	}
+}
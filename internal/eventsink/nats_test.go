@@ -0,0 +1,170 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// natsServer - сервер NATS с потоком JetStream, который подтверждает каждое сообщение PUB ответом PubAck
+// на адрес ответа. Первые rejects сообщений отклоняются ответом с ошибкой, сообщения сверх stored
+// не подтверждаются.
+type natsServer struct {
+	sync.Mutex
+	listener net.Listener
+	rejects  int
+	stored   int
+	connects int
+	subjects []string
+	messages []models.EventMessage
+}
+
+func newNATSServer(t *testing.T, rejects, stored int) *natsServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	s := &natsServer{listener: listener, rejects: rejects, stored: stored}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(t, conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *natsServer) serve(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+
+	s.Lock()
+	s.connects++
+	s.Unlock()
+
+	reader := bufio.NewReader(conn)
+	_, _ = fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+
+	var inbox, sid string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 3 && fields[0] == "SUB":
+			inbox, sid = strings.TrimSuffix(fields[1], "*"), fields[2]
+		case len(fields) == 4 && fields[0] == "PUB":
+			if !strings.HasPrefix(fields[2], inbox) {
+				t.Errorf("reply subject %q is not in inbox %q", fields[2], inbox)
+			}
+
+			size, _ := strconv.Atoi(fields[3])
+			payload := make([]byte, size+2)
+
+			if _, err = io.ReadFull(reader, payload); err != nil {
+				return
+			}
+
+			var m models.EventMessage
+			if err = json.Unmarshal(payload[:size], &m); err != nil {
+				t.Errorf("unmarshal message: %v", err)
+			}
+
+			s.Lock()
+			ack := fmt.Sprintf(`{"stream":"geo","seq":%d}`, len(s.messages)+1)
+
+			switch {
+			case s.rejects > 0:
+				s.rejects--
+				ack = `{"error":{"code":503,"description":"insufficient resources"}}`
+			case s.stored == 0:
+				ack = ""
+			default:
+				s.stored--
+				s.subjects = append(s.subjects, fields[1])
+				s.messages = append(s.messages, m)
+			}
+			s.Unlock()
+
+			if ack != "" {
+				_, _ = fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", fields[2], sid, len(ack), ack)
+			}
+		case len(fields) == 1 && fields[0] == "PING":
+			_, _ = fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestNATSSinkWrite(t *testing.T) {
+	t.Parallel()
+
+	server := newNATSServer(t, 1, 3)
+	defer server.listener.Close()
+
+	sink, err := NewNATSSink("nats://user:pass@"+server.listener.Addr().String(), "geo", 4)
+	if err != nil {
+		t.Fatalf("NewNATSSink() error = %v", err)
+	}
+	defer sink.Close()
+
+	batch := []models.Event{
+		{Sequence: 1, DeviceID: 5, Type: models.EventEnter},
+		{Sequence: 2, DeviceID: 6, Type: models.EventExit},
+	}
+
+	// первый пакет отклонен потоком, повтор выполняется через новое соединение
+	if err = sink.Write(context.Background(), batch); err == nil {
+		t.Fatal("Write() error = nil, want rejected batch")
+	}
+
+	if err = sink.Write(context.Background(), batch); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// пакет без подтверждения сохранения не принят
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err = sink.Write(ctx, batch); err == nil {
+		t.Fatal("Write() error = nil, want unacknowledged batch")
+	}
+
+	server.Lock()
+	defer server.Unlock()
+
+	if server.connects != 2 {
+		t.Errorf("connects = %d, want 2", server.connects)
+	}
+
+	wantSubjects := []string{"geo.2", "geo.1", "geo.2"}
+	if strings.Join(server.subjects, " ") != strings.Join(wantSubjects, " ") {
+		t.Errorf("subjects = %v, want %v", server.subjects, wantSubjects)
+	}
+
+	if last := server.messages[2]; last.Sequence != 2 || last.DeviceID != 6 || last.Type != "exit" {
+		t.Errorf("message = %+v, want exit of device 6", last)
+	}
+}
@@ -0,0 +1,331 @@
+package eventsink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/pkg/logger"
+)
+
+// outboxCompactRecords - количество записей журнала, после которого он перезаписывается
+// неподтвержденными событиями.
+const outboxCompactRecords = 10000
+
+// Outbox - исходящая очередь событий получателя. Publish дописывает события в журнал и возвращает
+// управление после сброса его на диск, события удаляются из очереди, когда получатель подтвердит их
+// вызовом Ack. События нумеруются очередью, номера продолжаются после перезапуска, поэтому по полю
+// Sequence получатель отбрасывает повторы.
+type Outbox struct {
+	sync.Mutex
+
+	// журнал очереди, пустая строка - очередь не сохраняется
+	path    string
+	file    *os.File
+	records int
+	// неподтвержденные события в порядке номеров
+	pending []models.Event
+	// номер последнего подтвержденного события
+	acked uint64
+	// номер следующего события, нумерация с 1
+	next uint64
+	// закрывается при публикации новых событий
+	published chan struct{}
+	log       *logger.Logger
+}
+
+// outboxRecord - запись журнала: событие, поставленное в очередь, или номер последнего подтвержденного события.
+type outboxRecord struct {
+	Event *models.Event `json:"event,omitempty"`
+	Acked uint64        `json:"acked,omitempty"`
+}
+
+// NewOutbox - восстанавливает неподтвержденные события из журнала path, отсутствующий файл - пустая очередь.
+func NewOutbox(path string, log *logger.Logger) (*Outbox, error) {
+	o := &Outbox{
+		path:      path,
+		next:      1,
+		published: make(chan struct{}),
+		log:       log,
+	}
+
+	if path == "" {
+		return o, nil
+	}
+
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+
+	if err := o.compact(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// Publish - постановка в очередь копий событий с номерами очереди.
+func (o *Outbox) Publish(events ...models.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	o.Lock()
+	defer o.Unlock()
+
+	records := make([]outboxRecord, 0, len(events))
+
+	for i := range events {
+		e := events[i]
+		e.Sequence = o.next
+		o.next++
+
+		o.pending = append(o.pending, e)
+		records = append(records, outboxRecord{Event: &e})
+	}
+
+	// события остаются в очереди в памяти и будут переданы, пока сервис работает
+	if err := o.append(records...); err != nil {
+		logger.LogError(errors.Wrapf(err, "[EVENT_SINK]::Publish : events %d-%d are not saved",
+			records[0].Event.Sequence, o.next-1), o.log)
+	}
+
+	close(o.published)
+	o.published = make(chan struct{})
+}
+
+// Pending - не больше limit самых старых неподтвержденных событий и канал, который закроется при публикации
+// новых событий.
+func (o *Outbox) Pending(limit int) ([]models.Event, <-chan struct{}) {
+	o.Lock()
+	defer o.Unlock()
+
+	if limit > len(o.pending) {
+		limit = len(o.pending)
+	}
+
+	return append([]models.Event(nil), o.pending[:limit]...), o.published
+}
+
+// Ack - подтверждение событий с номерами до sequence включительно, они удаляются из очереди. Ошибка
+// означает, что подтверждение не сохранено и события будут переданы повторно после перезапуска.
+func (o *Outbox) Ack(sequence uint64) error {
+	o.Lock()
+	defer o.Unlock()
+
+	if sequence <= o.acked {
+		return nil
+	}
+
+	o.apply(outboxRecord{Acked: sequence})
+
+	if err := o.append(outboxRecord{Acked: sequence}); err != nil {
+		return err
+	}
+
+	if o.records < outboxCompactRecords {
+		return nil
+	}
+
+	return o.compact()
+}
+
+func (o *Outbox) Close() error {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+
+	err := o.file.Close()
+	o.file = nil
+
+	return errors.Wrap(err, "close event sink queue")
+}
+
+// load - чтение журнала. Последняя запись без перевода строки оборвана сбоем во время записи и отбрасывается.
+func (o *Outbox) load() error {
+	file, err := os.Open(o.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "open event sink queue")
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "read event sink queue")
+		}
+
+		var r outboxRecord
+		if err = json.Unmarshal(line, &r); err != nil {
+			return errors.Wrap(err, "parse event sink queue")
+		}
+
+		o.apply(r)
+	}
+}
+
+// apply - изменение очереди записью журнала.
+func (o *Outbox) apply(r outboxRecord) {
+	if r.Event != nil && r.Event.Sequence > o.acked {
+		o.pending = append(o.pending, *r.Event)
+
+		if r.Event.Sequence >= o.next {
+			o.next = r.Event.Sequence + 1
+		}
+	}
+
+	if r.Acked <= o.acked {
+		return
+	}
+
+	o.acked = r.Acked
+	if o.acked >= o.next {
+		o.next = o.acked + 1
+	}
+
+	count := 0
+	for count < len(o.pending) && o.pending[count].Sequence <= o.acked {
+		count++
+	}
+
+	o.pending = o.pending[count:]
+}
+
+// append - запись в конец журнала со сбросом на диск, вызывается под блокировкой после изменения очереди.
+func (o *Outbox) append(records ...outboxRecord) error {
+	if o.path == "" {
+		return nil
+	}
+
+	if o.file == nil {
+		return errors.New("event sink queue is closed")
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for i := range records {
+		if err := encoder.Encode(records[i]); err != nil {
+			return errors.Wrap(err, "marshal event sink queue record")
+		}
+	}
+
+	_, err := o.file.Write(buf.Bytes())
+	if err == nil {
+		err = o.file.Sync()
+	}
+
+	if err == nil {
+		o.records += len(records)
+
+		return nil
+	}
+
+	// журнал мог остаться с оборванной записью, поэтому он перезаписывается состоянием очереди
+	if compactErr := o.compact(); compactErr != nil {
+		return errors.Wrap(err, "write event sink queue")
+	}
+
+	return nil
+}
+
+// compact - перезапись журнала номером последнего подтвержденного события и неподтвержденными событиями через
+// временный файл, чтобы при сбое не потерять прежнее состояние. Вызывается под блокировкой.
+func (o *Outbox) compact() error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+
+	records := []outboxRecord{{Acked: o.acked}}
+	for i := range o.pending {
+		records = append(records, outboxRecord{Event: &o.pending[i]})
+	}
+
+	for i := range records {
+		if err := encoder.Encode(records[i]); err != nil {
+			return errors.Wrap(err, "marshal event sink queue record")
+		}
+	}
+
+	tmp := o.path + ".tmp"
+
+	if err := writeSynced(tmp, buf.Bytes()); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, o.path); err != nil {
+		return errors.Wrap(err, "replace event sink queue")
+	}
+
+	if err := syncDir(filepath.Dir(o.path)); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND, 0o600) // nolint:gomnd // только владелец
+	if err != nil {
+		return errors.Wrap(err, "open event sink queue")
+	}
+
+	if o.file != nil {
+		_ = o.file.Close()
+	}
+
+	o.file, o.records = file, 0
+
+	return nil
+}
+
+// writeSynced - запись файла со сбросом на диск.
+func writeSynced(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) // nolint:gomnd // только владелец
+	if err != nil {
+		return errors.Wrap(err, "create event sink queue")
+	}
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "write event sink queue")
+	}
+
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "sync event sink queue")
+	}
+
+	return errors.Wrap(file.Close(), "close event sink queue")
+}
+
+// syncDir - сброс на диск каталога, чтобы переименование файла сохранилось при сбое.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "open event sink queue directory")
+	}
+
+	defer dir.Close()
+
+	return errors.Wrap(dir.Sync(), "sync event sink queue directory")
+}
@@ -0,0 +1,68 @@
+package eventsink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+func TestOutboxJournal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+
+	outbox, err := NewOutbox(path, nil)
+	if err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		outbox.Publish(models.Event{DeviceID: uint64(i), Type: models.EventEnter})
+	}
+
+	if err = outbox.Ack(2); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	_ = outbox.Close()
+
+	// запись, оборванная сбоем, отбрасывается
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+
+	if _, err = file.WriteString(`{"acked":`); err != nil {
+		t.Fatalf("write queue: %v", err)
+	}
+
+	_ = file.Close()
+
+	if outbox, err = NewOutbox(path, nil); err != nil {
+		t.Fatalf("NewOutbox() error = %v", err)
+	}
+
+	defer outbox.Close()
+
+	// номера событий продолжаются после перезапуска
+	outbox.Publish(models.Event{DeviceID: 9, Type: models.EventExit})
+
+	pending, _ := outbox.Pending(10)
+
+	want := []uint64{3, 4, 5}
+	if len(pending) != len(want) {
+		t.Fatalf("Pending() = %v, want sequences %v", pending, want)
+	}
+
+	for i := range want {
+		if pending[i].Sequence != want[i] {
+			t.Errorf("Pending() sequence %d = %d, want %d", i, pending[i].Sequence, want[i])
+		}
+	}
+
+	if pending[0].DeviceID != 2 || pending[2].DeviceID != 9 {
+		t.Errorf("Pending() = %+v, want devices 2 and 9 first and last", pending)
+	}
+}
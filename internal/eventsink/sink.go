@@ -0,0 +1,36 @@
+// Package eventsink - передача переходов устройств относительно геозон во внешние системы пакетами.
+package eventsink
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage"
+)
+
+// виды получателей
+const (
+	KindNATS = "nats"
+	KindFile = "file"
+)
+
+// New - получатель, заданный cfg.EventSink.
+func New(cfg *config.Config) (storage.EventSink, error) {
+	switch cfg.EventSink {
+	case KindNATS:
+		return NewNATSSink(cfg.EventSinkURL, cfg.EventSinkSubject, cfg.EventSinkPartitions)
+	case KindFile:
+		return NewFileSink(cfg.EventSinkURL)
+	}
+
+	return nil, errors.Errorf("unknown event sink %q", cfg.EventSink)
+}
+
+// partition - раздел темы для устройства: события одного устройства попадают в один раздел и сохраняют порядок.
+func partition(deviceID uint64, partitions int) uint64 {
+	if partitions < 1 {
+		return 0
+	}
+
+	return deviceID % uint64(partitions)
+}
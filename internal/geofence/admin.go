@@ -0,0 +1,124 @@
+package geofence
+
+import (
+	"context"
+	"time"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/internal/webhook"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// AdminServer - административные запросы к кэшу геозон.
+type AdminServer struct {
+	gf.UnimplementedGeofenceAdminServiceServer
+	geoCache storage.MemoryGeoCache
+	// доставка событий на webhook, nil - доставка отключена
+	webhooks *webhook.Sink
+}
+
+func NewAdminServer(geoCache storage.MemoryGeoCache, webhooks *webhook.Sink) *AdminServer {
+	return &AdminServer{
+		geoCache: geoCache,
+		webhooks: webhooks,
+	}
+}
+
+// GetQuarantine - запрос полигонов геозон, не загруженных в кэш из-за неисправимых дефектов геометрии.
+func (s *AdminServer) GetQuarantine(_ context.Context, request *gf.QuarantineRequest) (*gf.Quarantine, error) {
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	geofences, err := s.geoCache.GetQuarantine(userID)
+	if err != nil {
+		return nil, quarantineError(err)
+	}
+
+	grpcResponse := make([]*gf.QuarantinedGeofence, 0, len(geofences))
+
+	for i := 0; i < len(geofences); i++ {
+		grpcResponse = append(grpcResponse, &gf.QuarantinedGeofence{
+			GeofenceId: geofences[i].GeofenceID,
+			PolygonId:  geofences[i].PolygonID,
+			Title:      geofences[i].Title,
+			UserId:     geofences[i].UserID,
+			Reason:     geofences[i].Reason,
+			Issues:     toGeometryIssues(geofences[i]),
+		})
+	}
+
+	return &gf.Quarantine{
+		Geofences: grpcResponse,
+		Status:    gf.Status_OK,
+		Error:     "",
+	}, nil
+}
+
+func toGeometryIssues(q models.QuarantinedGeofence) []*gf.GeometryIssue {
+	issues := make([]*gf.GeometryIssue, 0, len(q.Issues))
+
+	for _, issue := range q.Issues {
+		issues = append(issues, &gf.GeometryIssue{
+			Defect:       string(issue.Defect),
+			PolygonIndex: uint32(issue.Member),
+			RingIndex:    uint32(issue.Ring),
+			Point:        toCoordinates(issue.Point),
+			Repaired:     issue.Repaired,
+		})
+	}
+
+	return issues
+}
+
+// GetDeadLetters - запрос событий, которые не удалось доставить на webhook пользователей.
+func (s *AdminServer) GetDeadLetters(_ context.Context, request *gf.DeadLettersRequest) (*gf.DeadLetters, error) {
+	if s.webhooks == nil {
+		return &gf.DeadLetters{Status: gf.Status_OK}, nil
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	letters := s.webhooks.DeadLetters(userID)
+	grpcResponse := make([]*gf.DeadLetter, 0, len(letters))
+
+	for i := range letters {
+		grpcResponse = append(grpcResponse, toDeadLetter(letters[i]))
+	}
+
+	return &gf.DeadLetters{
+		Letters: grpcResponse,
+		Status:  gf.Status_OK,
+		Error:   "",
+	}, nil
+}
+
+func toDeadLetter(d webhook.Delivery) *gf.DeadLetter {
+	p := d.Payload
+
+	return &gf.DeadLetter{
+		DeliveryId: d.ID,
+		UserId:     d.UserID,
+		Url:        d.URL,
+		Event: &gf.GeofenceEvent{
+			Sequence:   p.Sequence,
+			DeviceId:   p.DeviceID,
+			UserId:     p.UserID,
+			GeofenceId: p.GeofenceID,
+			PolygonId:  p.PolygonID,
+			Title:      p.Title,
+			Type:       toEventType(models.EventType(p.Type)),
+			Point:      toTrackPoint(models.TrackPoint{Point: orb.Point{p.Longitude, p.Latitude}, Time: p.Time}),
+		},
+		Attempts:  uint32(d.Attempts),
+		LastError: d.LastError,
+		FailedAt:  d.FailedAt.UnixNano() / int64(time.Millisecond),
+	}
+}
@@ -0,0 +1,104 @@
+package geofence
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/X-Keeper/geoborder/internal/storage"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// invalidRequestError - ошибка во входных данных запроса.
+type invalidRequestError struct {
+	msg string
+}
+
+func (e invalidRequestError) Error() string {
+	return e.msg
+}
+
+// invalidRequest - ошибка во входных данных запроса с форматированным текстом.
+func invalidRequest(format string, args ...interface{}) error {
+	return invalidRequestError{msg: fmt.Sprintf(format, args...)}
+}
+
+// failure - ответ на запрос с заполненными полями status и error.
+type failure interface {
+	proto.Message
+	GetStatus() gf.Status
+	GetError() string
+}
+
+// toStatus - статус ответа по ошибке обработки запроса.
+func toStatus(err error) gf.Status {
+	var invalid invalidRequestError
+
+	switch {
+	case errors.As(err, &invalid):
+		return gf.Status_BAD_REQUEST
+	case errors.Is(err, storage.ErrNotFound):
+		return gf.Status_NOT_FOUND
+	default:
+		return gf.Status_INTERNAL_SERVER_ERROR
+	}
+}
+
+// toCode - код gRPC, соответствующий статусу ответа.
+func toCode(s gf.Status) codes.Code {
+	switch s {
+	case gf.Status_OK:
+		return codes.OK
+	case gf.Status_NOT_FOUND:
+		return codes.NotFound
+	case gf.Status_BAD_REQUEST:
+		return codes.InvalidArgument
+	case gf.Status_INTERNAL_SERVER_ERROR:
+		return codes.Internal
+	}
+
+	return codes.Unknown
+}
+
+// replyError - ошибка gRPC для ответа, который не удалось сформировать. Код ошибки соответствует статусу
+// ответа, сам ответ с заполненными полями status и error передается в деталях статуса gRPC.
+func replyError(response failure) error {
+	st := status.New(toCode(response.GetStatus()), response.GetError())
+
+	if detailed, err := st.WithDetails(response); err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+func geofencesError(userID uint64, err error) error {
+	return replyError(&gf.Geofences{UserId: userID, Status: toStatus(err), Error: err.Error()})
+}
+
+func geometriesError(err error) error {
+	return replyError(&gf.GeofenceGeometries{Status: toStatus(err), Error: err.Error()})
+}
+
+func crossingsError(err error) error {
+	return replyError(&gf.TrackCrossings{Status: toStatus(err), Error: err.Error()})
+}
+
+func relationsError(err error) error {
+	return replyError(&gf.PolygonRelations{Status: toStatus(err), Error: err.Error()})
+}
+
+func detailsError(err error) error {
+	return replyError(&gf.GeofenceDetails{Status: toStatus(err), Error: err.Error()})
+}
+
+func quarantineError(err error) error {
+	return replyError(&gf.Quarantine{Status: toStatus(err), Error: err.Error()})
+}
+
+func eventsError(err error) error {
+	return replyError(&gf.DeviceEvents{Status: toStatus(err), Error: err.Error()})
+}
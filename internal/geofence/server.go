@@ -2,52 +2,79 @@ package geofence
 
 import (
 	"context"
+	"math"
 
 	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+	"github.com/paulmach/orb/geojson"
+	"github.com/pkg/errors"
 
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/events"
+	"github.com/X-Keeper/geoborder/internal/geometry"
 	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/internal/tracking"
 	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
 )
 
+const (
+	// количество ближайших геозон, если в запросе не указано
+	defaultNearestLimit = 1
+	// размер пикселя карты на экваторе при нулевом уровне масштаба, в метрах
+	equatorPixelSize = 156543.03
+	// допустимые границы координат, в градусах
+	maxLatitude  = 90
+	maxLongitude = 180
+)
+
 type GeoborderServer struct {
 	gf.UnimplementedGeofenceServiceServer
 	geoCache storage.MemoryGeoCache
+	// состояние устройств относительно геозон
+	tracker *tracking.Tracker
+	// шина переходов для подписчиков
+	bus *events.Bus
+	// максимальное количество точек в одном запросе
+	maxBatchSize int
+	// количество точек потока координат, ожидающих обработки
+	streamBufferSize int
 }
 
-func NewGeoborderServer(geoCache storage.MemoryGeoCache) *GeoborderServer {
+func NewGeoborderServer(
+	geoCache storage.MemoryGeoCache, tracker *tracking.Tracker, bus *events.Bus, cfg *config.Config,
+) *GeoborderServer {
 	return &GeoborderServer{
-		geoCache: geoCache,
+		geoCache:         geoCache,
+		tracker:          tracker,
+		bus:              bus,
+		maxBatchSize:     cfg.MaxBatchSize,
+		streamBufferSize: cfg.StreamBufferSize,
 	}
 }
 
 func (s *GeoborderServer) GetGeofencesByUserId(ctx context.Context, points *gf.UserPoints) (*gf.Geofences, error) {
+	if err := validatePoints(points.Items, s.maxBatchSize); err != nil {
+		return nil, geofencesError(points.UserId, err)
+	}
+
 	grpcResponse := make([]*gf.Geofence, 0, 1)
+	filter := toFilter(points.Filter)
 
 	for i := 0; i < len(points.Items); i++ {
 		geofences, err := s.geoCache.FindGeofenceByPoint(
-			orb.Point{
-				points.Items[i].Longitude,
-				points.Items[i].Latitude},
+			toPoint(points.Items[i]),
 			&points.UserId,
-			points.WithDistance)
+			points.WithDistance,
+			filter)
 
 		if err != nil {
-			return nil, err
+			return nil, geofencesError(points.UserId, err)
 		}
 
-		geoInfo := make([]*gf.GeofenceInfo, 0, len(geofences))
-
-		for j := 0; j < len(geofences); j++ {
-			geoInfo = append(geoInfo, &gf.GeofenceInfo{
-				GeofenceId: geofences[j].GeofenceID,
-				PolygonId:  geofences[j].PolygonID,
-				Title:      geofences[j].Title,
-				Distance:   geofences[j].Distance,
-			})
-		}
 		grpcResponse = append(grpcResponse, &gf.Geofence{
 			PointId: points.Items[i].PointId,
-			GeoInfo: geoInfo,
+			GeoInfo: toGeofenceInfo(geofences),
 		})
 	}
 
@@ -59,34 +86,38 @@ func (s *GeoborderServer) GetGeofencesByUserId(ctx context.Context, points *gf.U
 	}, nil
 }
 
+// CheckGeofenceByPoint - проверка вхождения точек в заданные геозоны. Если какой-либо из геозон нет в кэше,
+// то возвращается статус NOT_FOUND.
 func (s *GeoborderServer) CheckGeofenceByPoint(_ context.Context, req *gf.PointWithGeofence) (*gf.Geofences, error) {
+	if err := validatePoints(req.Points, s.maxBatchSize); err != nil {
+		return nil, geofencesError(0, err)
+	}
+
+	if len(req.GeofenceId) == 0 {
+		return nil, geofencesError(0, invalidRequest("geofence_id is required"))
+	}
+
+	if len(req.GeofenceId) > s.maxBatchSize {
+		return nil, geofencesError(0, invalidRequest("too many geofences: %d, max %d", len(req.GeofenceId), s.maxBatchSize))
+	}
+
 	grpcResponse := make([]*gf.Geofence, 0, 1)
+	filter := toFilter(req.Filter)
 
 	for i := 0; i < len(req.Points); i++ {
 		geofences, err := s.geoCache.CheckGeofenceByPoint(
-			orb.Point{
-				req.Points[i].Longitude,
-				req.Points[i].Latitude},
+			toPoint(req.Points[i]),
 			req.GeofenceId,
+			filter,
 		)
 
 		if err != nil {
-			return nil, err
+			return nil, geofencesError(0, err)
 		}
 
-		geoInfo := make([]*gf.GeofenceInfo, 0, len(geofences))
-
-		for j := 0; j < len(geofences); j++ {
-			geoInfo = append(geoInfo, &gf.GeofenceInfo{
-				GeofenceId: geofences[j].GeofenceID,
-				PolygonId:  geofences[j].PolygonID,
-				Title:      geofences[j].Title,
-				Distance:   geofences[j].Distance,
-			})
-		}
 		grpcResponse = append(grpcResponse, &gf.Geofence{
 			PointId: req.Points[i].PointId,
-			GeoInfo: geoInfo,
+			GeoInfo: toGeofenceInfo(geofences),
 		})
 	}
 
@@ -99,35 +130,532 @@ func (s *GeoborderServer) CheckGeofenceByPoint(_ context.Context, req *gf.PointW
 }
 
 // GetDistanceToGeofence - запрос дистанции до границы геозоны. Рассчитывается для каждого полигона из геозоны, в
-// который попадает геоточка.
+// который попадает геоточка. Если в запросе задан радиус поиска, то рассчитывается дистанция со знаком
+// до всех геозон, граница которых находится в пределах радиуса.
 func (s *GeoborderServer) GetDistanceToGeofence(_ context.Context, request *gf.Points) (*gf.Geofences, error) {
+	if err := validatePoints(request.Points, s.maxBatchSize); err != nil {
+		return nil, geofencesError(request.UserId, err)
+	}
+
+	if !validDistance(request.SearchRadius) {
+		return nil, geofencesError(request.UserId, invalidRequest("invalid search radius %v", request.SearchRadius))
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
 	grpcResponse := make([]*gf.Geofence, 0, 1)
+	filter := toFilter(request.Filter)
 
 	for i := 0; i < len(request.Points); i++ {
-		geofence, err := s.geoCache.GetDistanceToGeofence(orb.Point{request.Points[i].Longitude, request.Points[i].Latitude})
+		var geofence []models.Geofence
+		var err error
+
+		point := toPoint(request.Points[i])
+
+		if request.SearchRadius > 0 {
+			geofence, err = s.geoCache.FindNearbyGeofences(point, request.SearchRadius, userID, filter)
+		} else {
+			geofence, err = s.geoCache.GetDistanceToGeofence(point, userID, filter)
+		}
+
 		if err != nil {
-			return nil, err
+			return nil, geofencesError(request.UserId, err)
 		}
-		geoInfo := make([]*gf.GeofenceInfo, 0, len(geofence))
 
-		for j := 0; j < len(geofence); j++ {
-			geoInfo = append(geoInfo, &gf.GeofenceInfo{
-				GeofenceId: geofence[j].GeofenceID,
-				PolygonId:  geofence[j].PolygonID,
-				Title:      geofence[j].Title,
-				Distance:   geofence[j].Distance,
-			})
+		grpcResponse = append(grpcResponse, &gf.Geofence{
+			PointId: request.Points[i].PointId,
+			GeoInfo: toGeofenceInfo(geofence),
+		})
+	}
+
+	return &gf.Geofences{
+		UserId:   request.UserId,
+		Geofence: grpcResponse,
+		Status:   gf.Status_OK,
+		Error:    "",
+	}, nil
+}
+
+// GetNearestGeofences - запрос ближайших к точке геозон, упорядоченных по расстоянию до границы.
+// Для геозон, в которые попадает точка, дистанция отрицательная.
+func (s *GeoborderServer) GetNearestGeofences(_ context.Context, request *gf.NearestRequest) (*gf.Geofences, error) {
+	if err := validatePoints(request.Points, s.maxBatchSize); err != nil {
+		return nil, geofencesError(request.UserId, err)
+	}
+
+	if !validDistance(request.MaxDistance) {
+		return nil, geofencesError(request.UserId, invalidRequest("invalid max distance %v", request.MaxDistance))
+	}
+
+	grpcResponse := make([]*gf.Geofence, 0, len(request.Points))
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultNearestLimit
+	}
+
+	filter := toFilter(request.Filter)
+
+	for i := 0; i < len(request.Points); i++ {
+		geofence, err := s.geoCache.FindNearestGeofences(
+			toPoint(request.Points[i]),
+			userID,
+			limit,
+			request.MaxDistance,
+			filter)
+		if err != nil {
+			return nil, geofencesError(request.UserId, err)
 		}
+
 		grpcResponse = append(grpcResponse, &gf.Geofence{
 			PointId: request.Points[i].PointId,
-			GeoInfo: geoInfo,
+			GeoInfo: toGeofenceInfo(geofence),
 		})
 	}
 
 	return &gf.Geofences{
-		UserId:   0,
+		UserId:   request.UserId,
 		Geofence: grpcResponse,
 		Status:   gf.Status_OK,
 		Error:    "",
 	}, nil
 }
+
+// GetGeofencesInBounds - запрос геозон, попадающих в видимую область карты. Полигоны упрощаются
+// с допуском в один пиксель карты на заданном уровне масштаба.
+func (s *GeoborderServer) GetGeofencesInBounds(_ context.Context,
+	request *gf.BoundsRequest) (*gf.GeofenceGeometries, error) {
+	bound, err := toBound(request)
+	if err != nil {
+		return nil, geometriesError(err)
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	geofences, err := s.geoCache.FindGeofencesInBounds(bound, userID, zoomTolerance(request.Zoom, bound.Center().Lat()),
+		toFilter(request.Filter))
+	if err != nil {
+		return nil, geometriesError(err)
+	}
+
+	grpcResponse := make([]*gf.GeofenceGeometry, 0, len(geofences))
+
+	for i := 0; i < len(geofences); i++ {
+		data, err := geojson.NewGeometry(geofences[i].Geometry).MarshalJSON()
+		if err != nil {
+			return nil, geometriesError(err)
+		}
+
+		grpcResponse = append(grpcResponse, &gf.GeofenceGeometry{
+			GeofenceId: geofences[i].GeofenceID,
+			PolygonId:  geofences[i].PolygonID,
+			Title:      geofences[i].Title,
+			UserId:     geofences[i].UserID,
+			Kind:       toKind(geofences[i].Kind),
+			Geometry:   string(data),
+			Radius:     geofences[i].Radius,
+			Width:      geofences[i].Width,
+		})
+	}
+
+	return &gf.GeofenceGeometries{
+		Geofences: grpcResponse,
+		Status:    gf.Status_OK,
+		Error:     "",
+	}, nil
+}
+
+// GetTrackCrossings - запрос геозон, через которые прошел трек, с интерполированными точками
+// и временем входа и выхода.
+func (s *GeoborderServer) GetTrackCrossings(_ context.Context, request *gf.Track) (*gf.TrackCrossings, error) {
+	if err := validatePoints(request.Points, s.maxBatchSize); err != nil {
+		return nil, crossingsError(err)
+	}
+
+	track := make([]models.Point, 0, len(request.Points))
+
+	for i := 0; i < len(request.Points); i++ {
+		if i > 0 && request.Points[i].Timestamp < request.Points[i-1].Timestamp {
+			return nil, crossingsError(invalidRequest("track points are not ordered by timestamp"))
+		}
+
+		track = append(track, toPoint(request.Points[i]))
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	crossings, err := s.geoCache.FindTrackCrossings(track, userID, toFilter(request.Filter))
+	if err != nil {
+		return nil, crossingsError(err)
+	}
+
+	grpcResponse := make([]*gf.Crossing, 0, len(crossings))
+
+	for i := 0; i < len(crossings); i++ {
+		grpcResponse = append(grpcResponse, &gf.Crossing{
+			GeofenceId:    crossings[i].GeofenceID,
+			PolygonId:     crossings[i].PolygonID,
+			Title:         crossings[i].Title,
+			Entry:         toTrackPoint(crossings[i].Entry),
+			Exit:          toTrackPoint(crossings[i].Exit),
+			StartedInside: crossings[i].StartedInside,
+			EndedInside:   crossings[i].EndedInside,
+		})
+	}
+
+	return &gf.TrackCrossings{
+		Crossings: grpcResponse,
+		Status:    gf.Status_OK,
+		Error:     "",
+	}, nil
+}
+
+// GetGeofencesByPolygon - запрос геозон, которые пересекает, содержит или внутри которых лежит
+// полигон запроса, с площадью пересечения.
+func (s *GeoborderServer) GetGeofencesByPolygon(_ context.Context,
+	request *gf.PolygonRequest) (*gf.PolygonRelations, error) {
+	query, err := toGeometry(request)
+	if err == nil {
+		err = validateGeometry(query)
+	}
+
+	if err != nil {
+		return nil, relationsError(err)
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	relations, err := s.geoCache.FindGeofencesByPolygon(query, userID, toFilter(request.Filter))
+	if err != nil {
+		return nil, relationsError(err)
+	}
+
+	grpcResponse := make([]*gf.PolygonRelation, 0, len(relations))
+
+	for i := 0; i < len(relations); i++ {
+		grpcResponse = append(grpcResponse, &gf.PolygonRelation{
+			GeofenceId:  relations[i].GeofenceID,
+			PolygonId:   relations[i].PolygonID,
+			Title:       relations[i].Title,
+			Relation:    toRelation(relations[i].Relation),
+			OverlapArea: relations[i].OverlapArea,
+		})
+	}
+
+	return &gf.PolygonRelations{
+		Relations: grpcResponse,
+		Status:    gf.Status_OK,
+		Error:     "",
+	}, nil
+}
+
+// GetGeofence - запрос геометрии полигонов геозоны полного разрешения, их площади, периметра,
+// центра масс и описывающего прямоугольника.
+func (s *GeoborderServer) GetGeofence(_ context.Context, request *gf.GeofenceRequest) (*gf.GeofenceDetails, error) {
+	if request.GeofenceId == 0 && request.PolygonId == 0 {
+		return nil, detailsError(invalidRequest("geofence_id or polygon_id is required"))
+	}
+
+	details, err := s.geoCache.GetGeofence(request.GeofenceId, request.PolygonId, toFilter(request.Filter))
+	if err != nil {
+		return nil, detailsError(err)
+	}
+
+	grpcResponse := make([]*gf.PolygonDetails, 0, len(details))
+
+	for i := 0; i < len(details); i++ {
+		polygon := &gf.PolygonDetails{
+			GeofenceId: details[i].GeofenceID,
+			PolygonId:  details[i].PolygonID,
+			Title:      details[i].Title,
+			UserId:     details[i].UserID,
+			Kind:       toKind(details[i].Kind),
+			Radius:     details[i].Radius,
+			Width:      details[i].Width,
+			Area:       details[i].Measures.Area,
+			Perimeter:  details[i].Measures.Perimeter,
+			Centroid:   toCoordinates(details[i].Measures.Centroid),
+			BoundingBox: &gf.BoundingBox{
+				Min: toCoordinates(details[i].Measures.Bound.Min),
+				Max: toCoordinates(details[i].Measures.Bound.Max),
+			},
+		}
+
+		if request.Format == gf.GeometryFormat_WKB {
+			data, err := wkb.Marshal(details[i].Geometry)
+			if err != nil {
+				return nil, detailsError(err)
+			}
+
+			polygon.Geometry = &gf.PolygonDetails_Wkb{Wkb: data}
+		} else {
+			data, err := geojson.NewGeometry(details[i].Geometry).MarshalJSON()
+			if err != nil {
+				return nil, detailsError(err)
+			}
+
+			polygon.Geometry = &gf.PolygonDetails_Geojson{Geojson: string(data)}
+		}
+
+		grpcResponse = append(grpcResponse, polygon)
+	}
+
+	return &gf.GeofenceDetails{
+		Polygons: grpcResponse,
+		Status:   gf.Status_OK,
+		Error:    "",
+	}, nil
+}
+
+// TrackDevices - обработка точек устройств: переходы ENTER, EXIT и INSIDE относительно геозон
+// по сравнению с последним известным состоянием каждого устройства. Точки, которые старше последней
+// обработанной точки устройства, не обрабатываются и возвращаются в списке stale_points.
+func (s *GeoborderServer) TrackDevices(_ context.Context, request *gf.DevicePoints) (*gf.DeviceEvents, error) {
+	if err := validatePoints(request.Points, s.maxBatchSize); err != nil {
+		return nil, eventsError(err)
+	}
+
+	for i, p := range request.Points {
+		if p.DeviceId == 0 {
+			return nil, eventsError(invalidRequest("point %d: device_id is required", i))
+		}
+	}
+
+	var userID *uint64
+	if request.UserId != 0 {
+		userID = &request.UserId
+	}
+
+	grpcResponse := make([]*gf.DeviceEvent, 0, len(request.Points))
+	stale := make([]uint64, 0)
+
+	for _, p := range request.Points {
+		events, err := s.tracker.Track(p.DeviceId, userID, toPoint(p))
+		if errors.Is(err, tracking.ErrStalePoint) {
+			stale = append(stale, p.PointId)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, eventsError(err)
+		}
+
+		for i := 0; i < len(events); i++ {
+			grpcResponse = append(grpcResponse, toDeviceEvent(p.PointId, events[i]))
+		}
+	}
+
+	return &gf.DeviceEvents{
+		Events:      grpcResponse,
+		StalePoints: stale,
+		Status:      gf.Status_OK,
+		Error:       "",
+	}, nil
+}
+
+// toGeometry - разбор полигона запроса из GeoJSON или WKB.
+func toGeometry(request *gf.PolygonRequest) (orb.Geometry, error) {
+	switch g := request.Geometry.(type) {
+	case *gf.PolygonRequest_Geojson:
+		geometry, err := geojson.UnmarshalGeometry([]byte(g.Geojson))
+		if err != nil {
+			return nil, invalidRequest("invalid geojson: %s", err)
+		}
+
+		return geometry.Geometry(), nil
+	case *gf.PolygonRequest_Wkb:
+		geometry, err := wkb.Unmarshal(g.Wkb)
+		if err != nil {
+			return nil, invalidRequest("invalid wkb: %s", err)
+		}
+
+		return geometry, nil
+	default:
+		return nil, invalidRequest("geometry is required")
+	}
+}
+
+// toBound - преобразование области запроса. Если восточная граница меньше западной,
+// то область пересекает антимеридиан и её долгота выходит за 180°.
+func toBound(r *gf.BoundsRequest) (orb.Bound, error) {
+	if !inRange(r.MinLatitude, maxLatitude) || !inRange(r.MaxLatitude, maxLatitude) || r.MinLatitude > r.MaxLatitude ||
+		!inRange(r.MinLongitude, maxLongitude) || !inRange(r.MaxLongitude, maxLongitude) {
+		return orb.Bound{}, invalidRequest("invalid bounds")
+	}
+
+	east := r.MaxLongitude
+	if east < r.MinLongitude {
+		east += 2 * maxLongitude
+	}
+
+	return orb.Bound{
+		Min: orb.Point{r.MinLongitude, r.MinLatitude},
+		Max: orb.Point{east, r.MaxLatitude},
+	}, nil
+}
+
+// zoomTolerance - размер пикселя карты в метрах на широте lat при уровне масштаба zoom,
+// 0 - без упрощения.
+func zoomTolerance(zoom uint32, lat float64) float64 {
+	if zoom == 0 {
+		return 0
+	}
+
+	return math.Ldexp(equatorPixelSize*math.Cos(lat*math.Pi/180), -int(zoom))
+}
+
+// toPoint - преобразование точки запроса.
+func toPoint(p *gf.Point) models.Point {
+	return models.Point{
+		Point:       orb.Point{p.Longitude, p.Latitude},
+		Accuracy:    p.Accuracy,
+		Time:        p.Timestamp,
+		Altitude:    p.Altitude,
+		HasAltitude: p.HasAltitude,
+	}
+}
+
+// toFilter - преобразование условий отбора геозон, nil - без отбора.
+func toFilter(f *gf.Filter) *models.Filter {
+	filter := &models.Filter{
+		Categories: f.GetCategories(),
+		Tags:       f.GetTags(),
+		Attributes: f.GetAttributes(),
+	}
+
+	if filter.Empty() {
+		return nil
+	}
+
+	return filter
+}
+
+func toCoordinates(p orb.Point) *gf.Coordinates {
+	return &gf.Coordinates{
+		Latitude:  p.Lat(),
+		Longitude: p.Lon(),
+	}
+}
+
+func toTrackPoint(p models.TrackPoint) *gf.TrackPoint {
+	return &gf.TrackPoint{
+		Latitude:  p.Lat(),
+		Longitude: p.Lon(),
+		Timestamp: p.Time,
+	}
+}
+
+// toGeofenceInfo - преобразование найденных геозон в ответ.
+func toGeofenceInfo(geofences []models.Geofence) []*gf.GeofenceInfo {
+	geoInfo := make([]*gf.GeofenceInfo, 0, len(geofences))
+
+	for j := 0; j < len(geofences); j++ {
+		info := &gf.GeofenceInfo{
+			GeofenceId:       geofences[j].GeofenceID,
+			PolygonId:        geofences[j].PolygonID,
+			Title:            geofences[j].Title,
+			Distance:         geofences[j].Distance,
+			Containment:      toContainment(geofences[j].Containment),
+			RouteDistance:    geofences[j].RouteDistance,
+			RouteProgress:    geofences[j].RouteProgress,
+			VerticalDistance: geofences[j].VerticalDistance,
+			Category:         geofences[j].Category,
+			Tags:             geofences[j].Tags,
+			Attributes:       geofences[j].Attributes,
+		}
+
+		if boundary := geofences[j].Boundary; boundary != nil && boundary.Edge >= 0 {
+			info.NearestPoint = toCoordinates(boundary.Point)
+			info.Bearing = geofences[j].Bearing
+			info.PolygonIndex = uint32(boundary.Member)
+			info.RingIndex = uint32(boundary.Ring)
+			info.EdgeIndex = uint32(boundary.Edge)
+		}
+
+		geoInfo = append(geoInfo, info)
+	}
+
+	return geoInfo
+}
+
+func toDeviceEvent(pointID uint64, e models.Event) *gf.DeviceEvent {
+	return &gf.DeviceEvent{
+		DeviceId:   e.DeviceID,
+		PointId:    pointID,
+		GeofenceId: e.GeofenceID,
+		PolygonId:  e.PolygonID,
+		Title:      e.Title,
+		Type:       toEventType(e.Type),
+		Point:      toTrackPoint(e.Point),
+	}
+}
+
+func toEventType(t models.EventType) gf.EventType {
+	switch t {
+	case models.EventEnter:
+		return gf.EventType_EVENT_ENTER
+	case models.EventExit:
+		return gf.EventType_EVENT_EXIT
+	case models.EventInside:
+		return gf.EventType_EVENT_INSIDE
+	case models.EventDwell:
+		return gf.EventType_EVENT_DWELL
+	}
+
+	return gf.EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func toContainment(c models.Containment) gf.Containment {
+	switch c {
+	case models.ContainmentInside:
+		return gf.Containment_INSIDE
+	case models.ContainmentOutside:
+		return gf.Containment_OUTSIDE
+	case models.ContainmentBoundaryUncertain:
+		return gf.Containment_BOUNDARY_UNCERTAIN
+	}
+
+	return gf.Containment_CONTAINMENT_UNSPECIFIED
+}
+
+func toKind(k models.GeofenceKind) gf.GeofenceKind {
+	switch k {
+	case models.KindCircle:
+		return gf.GeofenceKind_CIRCLE
+	case models.KindCorridor:
+		return gf.GeofenceKind_CORRIDOR
+	}
+
+	return gf.GeofenceKind_POLYGON
+}
+
+func toRelation(r geometry.Relation) gf.Relation {
+	switch r {
+	case geometry.RelationIntersects:
+		return gf.Relation_INTERSECTS
+	case geometry.RelationContains:
+		return gf.Relation_CONTAINS
+	case geometry.RelationWithin:
+		return gf.Relation_WITHIN
+	}
+
+	return gf.Relation_RELATION_UNSPECIFIED
+}
@@ -0,0 +1,104 @@
+package geofence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// usersCache - кэш с геозоной 1 пользователя 1 и геозоной 2 пользователя 2 рядом с любой точкой.
+type usersCache struct {
+	storage.MemoryGeoCache
+}
+
+func (usersCache) byUser(userID *uint64) []models.Geofence {
+	var res []models.Geofence
+
+	for _, gz := range []models.Geofence{{GeofenceID: 1, UserID: 1}, {GeofenceID: 2, UserID: 2}} {
+		if userID == nil || *userID == gz.UserID {
+			res = append(res, gz)
+		}
+	}
+
+	return res
+}
+
+func (c usersCache) GetDistanceToGeofence(_ models.Point, userID *uint64,
+	_ *models.Filter) ([]models.Geofence, error) {
+	return c.byUser(userID), nil
+}
+
+func (c usersCache) FindNearbyGeofences(_ models.Point, _ float64, userID *uint64,
+	_ *models.Filter) ([]models.Geofence, error) {
+	return c.byUser(userID), nil
+}
+
+func TestGetDistanceToGeofenceUser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		radius float64
+		userID uint64
+		want   int
+	}{
+		{name: "user without radius", userID: 2, want: 1},
+		{name: "user with radius", radius: 100, userID: 2, want: 1},
+		{name: "all users with radius", radius: 100, want: 2},
+	}
+
+	server := NewGeoborderServer(usersCache{}, nil, nil, &config.Config{MaxBatchSize: 10})
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			response, err := server.GetDistanceToGeofence(context.Background(), &gf.Points{
+				Points:       []*gf.Point{{PointId: 1, Latitude: 10, Longitude: 10}},
+				SearchRadius: tt.radius,
+				UserId:       tt.userID,
+			})
+			if err != nil {
+				t.Fatalf("GetDistanceToGeofence() error = %v", err)
+			}
+
+			got := response.Geofence[0].GeoInfo
+			if len(got) != tt.want {
+				t.Fatalf("GetDistanceToGeofence() = %v, want %d geofences", got, tt.want)
+			}
+
+			for _, info := range got {
+				if tt.userID != 0 && info.GeofenceId != 2 {
+					t.Errorf("geofence %d of another user", info.GeofenceId)
+				}
+			}
+		})
+	}
+}
+
+func TestEnumsUnspecified(t *testing.T) {
+	t.Parallel()
+
+	// нулевое значение перечислений не совпадает ни с одним результатом
+	if got := toContainment(models.ContainmentInside); got == gf.Containment_CONTAINMENT_UNSPECIFIED {
+		t.Errorf("toContainment(inside) = %v", got)
+	}
+
+	if got := toEventType(models.EventEnter); got == gf.EventType_EVENT_TYPE_UNSPECIFIED {
+		t.Errorf("toEventType(enter) = %v", got)
+	}
+
+	if got := toEventType(models.EventType("unknown")); got != gf.EventType_EVENT_TYPE_UNSPECIFIED {
+		t.Errorf("toEventType(unknown) = %v, want EVENT_TYPE_UNSPECIFIED", got)
+	}
+
+	if got := toRelation(geometry.RelationIntersects); got != gf.Relation_INTERSECTS {
+		t.Errorf("toRelation(intersects) = %v, want INTERSECTS", got)
+	}
+}
@@ -0,0 +1,105 @@
+package geofence
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/tracking"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// StreamPositions - поток координат устройств. На каждую точку отправляется ответ: для точки с device_id -
+// переходы устройства относительно геозон, иначе - геозоны, в которые попадает точка.
+// Точки читаются из потока в буфер размером streamBufferSize и обрабатываются по порядку. Если клиент
+// не успевает читать ответы, то отправка блокируется, буфер заполняется и чтение точек приостанавливается,
+// после чего управление потоком gRPC останавливает отправку точек клиентом.
+func (s *GeoborderServer) StreamPositions(stream gf.GeofenceService_StreamPositionsServer) error {
+	ctx := stream.Context()
+	points := make(chan *gf.Point, s.streamBufferSize)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		defer close(points)
+
+		for {
+			p, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					recvErr <- err
+				}
+
+				return
+			}
+
+			select {
+			case points <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for p := range points {
+		if err := stream.Send(s.position(p)); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+// position - ответ на точку потока координат. Ошибка обработки точки возвращается в полях status и error.
+func (s *GeoborderServer) position(p *gf.Point) *gf.PositionUpdate {
+	update := &gf.PositionUpdate{
+		PointId:  p.GetPointId(),
+		DeviceId: p.GetDeviceId(),
+		Status:   gf.Status_OK,
+		Error:    "",
+	}
+
+	if err := validatePoint(p); err != nil {
+		update.Status, update.Error = toStatus(err), err.Error()
+
+		return update
+	}
+
+	var userID *uint64
+	if p.UserId != 0 {
+		userID = &p.UserId
+	}
+
+	if p.DeviceId == 0 {
+		geofences, err := s.geoCache.FindGeofenceByPoint(toPoint(p), userID, false, nil)
+		if err != nil {
+			update.Status, update.Error = toStatus(err), err.Error()
+
+			return update
+		}
+
+		update.Geofences = toGeofenceInfo(geofences)
+
+		return update
+	}
+
+	events, err := s.tracker.Track(p.DeviceId, userID, toPoint(p))
+
+	switch {
+	case errors.Is(err, tracking.ErrStalePoint):
+		update.Stale = true
+	case err != nil:
+		update.Status, update.Error = toStatus(err), err.Error()
+	default:
+		update.Events = make([]*gf.DeviceEvent, 0, len(events))
+		for i := 0; i < len(events); i++ {
+			update.Events = append(update.Events, toDeviceEvent(p.PointId, events[i]))
+		}
+	}
+
+	return update
+}
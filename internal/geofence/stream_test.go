@@ -0,0 +1,174 @@
+package geofence
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/internal/tracking"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// eastCache - кэш с одной геозоной, в которую попадают точки восточного полушария.
+type eastCache struct {
+	storage.MemoryGeoCache
+}
+
+func (eastCache) FindGeofenceByPoint(point models.Point, _ *uint64, _ bool,
+	_ *models.Filter) ([]models.Geofence, error) {
+	if point.Lon() <= 0 {
+		return nil, nil
+	}
+
+	return []models.Geofence{{GeofenceID: 1, PolygonID: 10, Title: "east"}}, nil
+}
+
+// positionStream - поток координат: точки читаются из in, ответы отправляются в out.
+type positionStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	in       chan *gf.Point
+	out      chan *gf.PositionUpdate
+	received int32
+}
+
+func (s *positionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *positionStream) Recv() (*gf.Point, error) {
+	p, ok := <-s.in
+	if !ok {
+		return nil, io.EOF
+	}
+
+	atomic.AddInt32(&s.received, 1)
+
+	return p, nil
+}
+
+func (s *positionStream) Send(update *gf.PositionUpdate) error {
+	s.out <- update
+
+	return nil
+}
+
+func newStreamServer(bufferSize int) *GeoborderServer {
+	cache := eastCache{}
+
+	tracker := tracking.NewTracker(cache, nil, 0, 0)
+
+	return NewGeoborderServer(cache, tracker, nil, &config.Config{StreamBufferSize: bufferSize})
+}
+
+func TestStreamPositions(t *testing.T) {
+	t.Parallel()
+
+	points := []*gf.Point{
+		{PointId: 1, Longitude: 10},
+		{PointId: 2, Latitude: 100},
+		{PointId: 3, Longitude: -10, DeviceId: 5},
+		{PointId: 4, Longitude: 10, DeviceId: 5},
+	}
+
+	stream := &positionStream{
+		ctx: context.Background(),
+		in:  make(chan *gf.Point, len(points)),
+		out: make(chan *gf.PositionUpdate, len(points)),
+	}
+
+	for _, p := range points {
+		stream.in <- p
+	}
+
+	close(stream.in)
+
+	if err := newStreamServer(1).StreamPositions(stream); err != nil {
+		t.Fatalf("StreamPositions() error = %v", err)
+	}
+
+	close(stream.out)
+
+	updates := make([]*gf.PositionUpdate, 0, len(points))
+	for update := range stream.out {
+		updates = append(updates, update)
+	}
+
+	if len(updates) != len(points) {
+		t.Fatalf("StreamPositions() sent %d updates, want %d", len(updates), len(points))
+	}
+
+	for i, update := range updates {
+		if update.PointId != points[i].PointId {
+			t.Errorf("update %d: point_id = %d, want %d", i, update.PointId, points[i].PointId)
+		}
+	}
+
+	if len(updates[0].Geofences) != 1 || updates[0].Status != gf.Status_OK {
+		t.Errorf("update 0 = %v, want one geofence", updates[0])
+	}
+
+	if updates[1].Status != gf.Status_BAD_REQUEST || updates[1].Error == "" {
+		t.Errorf("update 1 = %v, want BAD_REQUEST", updates[1])
+	}
+
+	if len(updates[2].Events) != 0 {
+		t.Errorf("update 2 = %v, want no events", updates[2])
+	}
+
+	if len(updates[3].Events) != 1 || updates[3].Events[0].Type != gf.EventType_EVENT_ENTER {
+		t.Errorf("update 3 = %v, want enter event", updates[3])
+	}
+}
+
+func TestStreamPositionsBackpressure(t *testing.T) {
+	t.Parallel()
+
+	const (
+		bufferSize = 2
+		count      = 20
+	)
+
+	stream := &positionStream{
+		ctx: context.Background(),
+		in:  make(chan *gf.Point, count),
+		out: make(chan *gf.PositionUpdate),
+	}
+
+	for i := 0; i < count; i++ {
+		stream.in <- &gf.Point{PointId: uint64(i), Longitude: 10}
+	}
+
+	close(stream.in)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- newStreamServer(bufferSize).StreamPositions(stream)
+	}()
+
+	// клиент не читает ответы: сервер читает не больше буфера, точки в обработке и точки,
+	// ожидающей места в буфере
+	time.Sleep(50 * time.Millisecond)
+
+	if received := atomic.LoadInt32(&stream.received); received > bufferSize+2 {
+		t.Errorf("received %d points while client is not reading, want at most %d", received, bufferSize+2)
+	}
+
+	for i := 0; i < count; i++ {
+		if update := <-stream.out; update.PointId != uint64(i) {
+			t.Fatalf("update %d: point_id = %d", i, update.PointId)
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("StreamPositions() error = %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package geofence
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// validatePoints - проверка списка точек запроса: список не пустой, не длиннее maxBatchSize,
+// координаты каждой точки в допустимых пределах.
+func validatePoints(points []*gf.Point, maxBatchSize int) error {
+	if len(points) == 0 {
+		return invalidRequest("points are required")
+	}
+
+	if len(points) > maxBatchSize {
+		return invalidRequest("too many points: %d, max %d", len(points), maxBatchSize)
+	}
+
+	for i, p := range points {
+		if err := validatePoint(p); err != nil {
+			return invalidRequest("point %d: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validatePoint - проверка координат, точности и высоты точки.
+func validatePoint(p *gf.Point) error {
+	switch {
+	case p == nil:
+		return invalidRequest("point is required")
+	case !inRange(p.Latitude, maxLatitude):
+		return invalidRequest("latitude %v out of range", p.Latitude)
+	case !inRange(p.Longitude, maxLongitude):
+		return invalidRequest("longitude %v out of range", p.Longitude)
+	case !validDistance(p.Accuracy):
+		return invalidRequest("invalid accuracy %v", p.Accuracy)
+	case p.HasAltitude && (math.IsNaN(p.Altitude) || math.IsInf(p.Altitude, 0)):
+		return invalidRequest("invalid altitude %v", p.Altitude)
+	}
+
+	return nil
+}
+
+// validateGeometry - геометрия запроса является полигоном или мультиполигоном с допустимыми координатами.
+func validateGeometry(g orb.Geometry) error {
+	var members orb.MultiPolygon
+
+	switch polygon := g.(type) {
+	case orb.Polygon:
+		members = orb.MultiPolygon{polygon}
+	case orb.MultiPolygon:
+		members = polygon
+	default:
+		return invalidRequest("polygon or multipolygon expected")
+	}
+
+	if len(members) == 0 {
+		return invalidRequest("empty geometry")
+	}
+
+	for _, polygon := range members {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				if !geometry.ValidCoordinates(p) {
+					return invalidRequest("coordinates (%v, %v) out of range", p.Lon(), p.Lat())
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// inRange - значение конечно и по модулю не больше limit. NaN в диапазон не попадает.
+func inRange(v, limit float64) bool {
+	return v >= -limit && v <= limit
+}
+
+// validDistance - расстояние конечно и неотрицательно, 0 - не задано.
+func validDistance(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 1)
+}
@@ -0,0 +1,105 @@
+package geofence
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/X-Keeper/geoborder/internal/storage"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+func TestValidatePoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		points  []*gf.Point
+		wantErr bool
+	}{
+		{name: "valid", points: []*gf.Point{{Latitude: 47.2, Longitude: 39.7, Accuracy: 10}}},
+		{name: "empty", points: nil, wantErr: true},
+		{name: "too many", points: []*gf.Point{{}, {}, {}}, wantErr: true},
+		{name: "nil point", points: []*gf.Point{nil}, wantErr: true},
+		{name: "latitude out of range", points: []*gf.Point{{Latitude: 91}}, wantErr: true},
+		{name: "longitude out of range", points: []*gf.Point{{Longitude: -180.5}}, wantErr: true},
+		{name: "NaN latitude", points: []*gf.Point{{Latitude: math.NaN()}}, wantErr: true},
+		{name: "negative accuracy", points: []*gf.Point{{Accuracy: -1}}, wantErr: true},
+		{name: "infinite altitude", points: []*gf.Point{{Altitude: math.Inf(1), HasAltitude: true}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePoints(tt.points, 2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && toStatus(err) != gf.Status_BAD_REQUEST {
+				t.Errorf("toStatus() = %v, want BAD_REQUEST", toStatus(err))
+			}
+		})
+	}
+}
+
+func TestReplyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantStatus gf.Status
+	}{
+		{
+			name:       "invalid request",
+			err:        invalidRequest("points are required"),
+			wantCode:   codes.InvalidArgument,
+			wantStatus: gf.Status_BAD_REQUEST,
+		},
+		{
+			name:       "not found",
+			err:        errors.Wrap(storage.ErrNotFound, "geofence 1"),
+			wantCode:   codes.NotFound,
+			wantStatus: gf.Status_NOT_FOUND,
+		},
+		{
+			name:       "internal",
+			err:        errors.New("cache failure"),
+			wantCode:   codes.Internal,
+			wantStatus: gf.Status_INTERNAL_SERVER_ERROR,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			st := status.Convert(geofencesError(7, tt.err))
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("details = %v, want one response", details)
+			}
+
+			response, ok := details[0].(*gf.Geofences)
+			if !ok {
+				t.Fatalf("detail = %T, want *Geofences", details[0])
+			}
+
+			if response.Status != tt.wantStatus || response.Error != tt.err.Error() || response.UserId != 7 {
+				t.Errorf("response = %v, want status %v, error %q", response, tt.wantStatus, tt.err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package geofence
+
+import (
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	gf "github.com/X-Keeper/geoborder/pkg/api/proto"
+)
+
+// WatchGeofenceEvents - подписка на переходы ENTER, EXIT и DWELL устройств. Поток продолжается, пока клиент
+// не отменит запрос. После переподключения клиент передает from_sequence - номер, следующий за последним
+// полученным событием.
+func (s *GeoborderServer) WatchGeofenceEvents(
+	request *gf.WatchRequest, stream gf.GeofenceService_WatchGeofenceEventsServer,
+) error {
+	filter := &models.EventFilter{
+		UserIDs:     request.UserId,
+		GeofenceIDs: request.GeofenceId,
+		DeviceIDs:   request.DeviceId,
+	}
+
+	return s.bus.Watch(stream.Context(), request.FromSequence, filter, func(e models.Event) error {
+		return stream.Send(toGeofenceEvent(e))
+	})
+}
+
+func toGeofenceEvent(e models.Event) *gf.GeofenceEvent {
+	return &gf.GeofenceEvent{
+		Sequence:   e.Sequence,
+		DeviceId:   e.DeviceID,
+		UserId:     e.UserID,
+		GeofenceId: e.GeofenceID,
+		PolygonId:  e.PolygonID,
+		Title:      e.Title,
+		Type:       toEventType(e.Type),
+		Point:      toTrackPoint(e.Point),
+	}
+}
@@ -0,0 +1,220 @@
+package geometry
+
+import (
+	"github.com/paulmach/orb"
+)
+
+const (
+	maxLon      = 180.0
+	fullLon     = 360.0
+	maxLatitude = 90.0
+)
+
+// unwrapRing - устраняет разрывы долготы при пересечении антимеридиана: если соседние вершины
+// отличаются по долготе больше чем на 180°, то последующие вершины сдвигаются на 360°.
+// Если кольцо не пересекает антимеридиан, то возвращается исходное кольцо.
+func unwrapRing(r orb.Ring) (orb.Ring, bool) {
+	var res orb.Ring
+
+	shift := 0.0
+
+	for i := 1; i < len(r); i++ {
+		switch d := r[i][0] - r[i-1][0]; {
+		case d > maxLon:
+			shift -= fullLon
+		case d < -maxLon:
+			shift += fullLon
+		}
+
+		if shift != 0 && res == nil {
+			res = r.Clone()
+		}
+
+		if res != nil {
+			res[i][0] = r[i][0] + shift
+		}
+	}
+
+	if res == nil {
+		return r, false
+	}
+
+	return res, true
+}
+
+// unwrapPolygon - устраняет разрывы долготы во всех кольцах полигона. Дыры сдвигаются так,
+// чтобы они оказались в той же полосе долгот, что и внешнее кольцо.
+func unwrapPolygon(p orb.Polygon) (orb.Polygon, bool) {
+	res := make(orb.Polygon, len(p))
+	crossing := false
+
+	for i := range p {
+		var unwrapped bool
+
+		res[i], unwrapped = unwrapRing(p[i])
+		crossing = crossing || unwrapped
+	}
+
+	if !crossing {
+		return p, false
+	}
+
+	outer := res[0].Bound()
+	for i := 1; i < len(res); i++ {
+		res[i] = alignRing(res[i], outer)
+	}
+
+	return res, true
+}
+
+// alignRing - сдвигает кольцо на 360° так, чтобы его долготы попали в полосу долгот bound.
+func alignRing(r orb.Ring, bound orb.Bound) orb.Ring {
+	center := r.Bound().Center()[0]
+
+	shift := 0.0
+	if center-bound.Center()[0] > maxLon {
+		shift = -fullLon
+	} else if bound.Center()[0]-center > maxLon {
+		shift = fullLon
+	}
+
+	if shift == 0 {
+		return r
+	}
+
+	res := r.Clone()
+	for i := range res {
+		res[i][0] += shift
+	}
+
+	return res
+}
+
+// unwrapLine - устраняет разрывы долготы в линии.
+func unwrapLine(ls orb.LineString) orb.LineString {
+	r, _ := unwrapRing(orb.Ring(ls))
+
+	return orb.LineString(r)
+}
+
+// SplitAntimeridian - разбивает прямоугольник, долгота которого выходит за пределы [-180, 180],
+// на прямоугольники по обе стороны антимеридиана.
+func SplitAntimeridian(b orb.Bound) []orb.Bound {
+	switch {
+	case b.Max[0]-b.Min[0] >= fullLon:
+		return []orb.Bound{{Min: orb.Point{-maxLon, b.Min[1]}, Max: orb.Point{maxLon, b.Max[1]}}}
+	case b.Min[0] < -maxLon:
+		return []orb.Bound{
+			{Min: orb.Point{b.Min[0] + fullLon, b.Min[1]}, Max: orb.Point{maxLon, b.Max[1]}},
+			{Min: orb.Point{-maxLon, b.Min[1]}, Max: b.Max},
+		}
+	case b.Max[0] > maxLon:
+		return []orb.Bound{
+			{Min: b.Min, Max: orb.Point{maxLon, b.Max[1]}},
+			{Min: orb.Point{-maxLon, b.Min[1]}, Max: orb.Point{b.Max[0] - fullLon, b.Max[1]}},
+		}
+	default:
+		return []orb.Bound{b}
+	}
+}
+
+// wrapPoint - возвращает точку и её копии со сдвигом долготы на ±360°, которые попадают
+// в полосу долгот bound. Используется для проверки вхождения в геометрию, пересекающую антимеридиан.
+func wrapPoint(b orb.Bound, p orb.Point) []orb.Point {
+	points := []orb.Point{p}
+
+	if b.Max[0] > maxLon && p[0]+fullLon <= b.Max[0] {
+		points = append(points, orb.Point{p[0] + fullLon, p[1]})
+	}
+
+	if b.Min[0] < -maxLon && p[0]-fullLon >= b.Min[0] {
+		points = append(points, orb.Point{p[0] - fullLon, p[1]})
+	}
+
+	return points
+}
+
+// segmentShifts - сдвиги долготы отрезка ab на 0 или ±360°, при которых его прямоугольник пересекает bound.
+func segmentShifts(bound orb.Bound, a, b orb.Point) []float64 {
+	segment := orb.MultiPoint{a, b}.Bound()
+	shifts := make([]float64, 0, 1)
+
+	for _, shift := range []float64{0, fullLon, -fullLon} {
+		shifted := orb.Bound{
+			Min: orb.Point{segment.Min[0] + shift, segment.Min[1]},
+			Max: orb.Point{segment.Max[0] + shift, segment.Max[1]},
+		}
+
+		if shifted.Intersects(bound) {
+			shifts = append(shifts, shift)
+		}
+	}
+
+	return shifts
+}
+
+// UnwrapSegment - сдвигает конец отрезка b на 360° так, чтобы разница долгот концов не превышала 180°.
+func UnwrapSegment(a, b orb.Point) orb.Point {
+	switch d := b[0] - a[0]; {
+	case d > maxLon:
+		b[0] -= fullLon
+	case d < -maxLon:
+		b[0] += fullLon
+	}
+
+	return b
+}
+
+// NormalizePoint - приводит долготу точки к диапазону [-180, 180].
+func NormalizePoint(p orb.Point) orb.Point {
+	for p[0] > maxLon {
+		p[0] -= fullLon
+	}
+
+	for p[0] < -maxLon {
+		p[0] += fullLon
+	}
+
+	return p
+}
+
+// NormalizeGeometry - копия точки, линии, полигона или мультиполигона с долготой вершин, приведенной
+// к диапазону [-180, 180]. Так геометрия, пересекающая антимеридиан, возвращается в том же виде,
+// в котором задается в GeoJSON.
+func NormalizeGeometry(g orb.Geometry) orb.Geometry {
+	switch geometry := g.(type) {
+	case orb.Point:
+		return NormalizePoint(geometry)
+	case orb.LineString:
+		return normalizePoints(geometry)
+	case orb.Polygon:
+		return normalizePolygon(geometry)
+	case orb.MultiPolygon:
+		res := make(orb.MultiPolygon, 0, len(geometry))
+		for i := 0; i < len(geometry); i++ {
+			res = append(res, normalizePolygon(geometry[i]))
+		}
+
+		return res
+	default:
+		return g
+	}
+}
+
+func normalizePolygon(p orb.Polygon) orb.Polygon {
+	res := make(orb.Polygon, 0, len(p))
+	for i := 0; i < len(p); i++ {
+		res = append(res, orb.Ring(normalizePoints(orb.LineString(p[i]))))
+	}
+
+	return res
+}
+
+func normalizePoints(points orb.LineString) orb.LineString {
+	res := make(orb.LineString, 0, len(points))
+	for i := 0; i < len(points); i++ {
+		res = append(res, NormalizePoint(points[i]))
+	}
+
+	return res
+}
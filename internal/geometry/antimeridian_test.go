@@ -0,0 +1,68 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestPolygons_ContainsAcrossAntimeridian(t *testing.T) {
+	// полигон вокруг Чукотки от 170° в.д. до 170° з.д.
+	shape, ok := NewPolygons(orb.Polygon{{{170, 64}, {-170, 64}, {-170, 68}, {170, 68}, {170, 64}}})
+	if !ok {
+		t.Fatal("NewPolygons() failed")
+	}
+
+	tests := []struct {
+		name  string
+		point orb.Point
+		want  bool
+	}{
+		{name: "east of dateline", point: orb.Point{175, 66}, want: true},
+		{name: "west of dateline", point: orb.Point{-175, 66}, want: true},
+		{name: "opposite side of the planet", point: orb.Point{0, 66}, want: false},
+		{name: "outside by latitude", point: orb.Point{-175, 70}, want: false},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := shape.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitAntimeridian(t *testing.T) {
+	t.Parallel()
+
+	got := SplitAntimeridian(orb.Bound{Min: orb.Point{170, 64}, Max: orb.Point{190, 68}})
+	want := []orb.Bound{
+		{Min: orb.Point{170, 64}, Max: orb.Point{180, 68}},
+		{Min: orb.Point{-180, 64}, Max: orb.Point{-170, 68}},
+	}
+
+	if len(got) != len(want) || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Errorf("SplitAntimeridian() got = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeGeometry(t *testing.T) {
+	t.Parallel()
+
+	polygon, _ := unwrapPolygon(orb.Polygon{{{179, 0}, {-179, 0}, {-179, 1}, {179, 1}, {179, 0}}})
+	got, ok := NormalizeGeometry(polygon).(orb.Polygon)
+	want := orb.Polygon{{{179, 0}, {-179, 0}, {-179, 1}, {179, 1}, {179, 0}}}
+
+	if !ok || !got.Equal(want) {
+		t.Errorf("NormalizeGeometry() got = %v, want %v", got, want)
+	}
+
+	if polygon[0][1][0] != 181 {
+		t.Errorf("NormalizeGeometry() changed source geometry %v", polygon)
+	}
+}
@@ -0,0 +1,233 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// Площади считаются на сфере радиуса orb.EarthRadius для полигонов, рёбра которых - отрезки
+// на плоскости долгота-широта, так же как выполняется проверка вхождения точки. Площадь области
+// равна криволинейному интегралу -R² ∮ sin(lat) d(lon) по её границе, ориентированной против часовой стрелки.
+
+// Area - площадь полигонов в квадратных метрах.
+func Area(members orb.MultiPolygon) float64 {
+	area := 0.0
+
+	for _, polygon := range members {
+		for i, r := range polygon {
+			a := math.Abs(ringIntegral(r))
+			if i > 0 {
+				a = -a
+			}
+
+			area += a
+		}
+	}
+
+	return area
+}
+
+// overlap - результат обхода границ двух полигонов.
+type overlap struct {
+	// площадь пересечения, в квадратных метрах
+	area float64
+	// часть границы первого полигона лежит внутри второго и наоборот
+	aInB, bInA bool
+	// часть границы первого полигона лежит вне второго и наоборот
+	aOutB, bOutA bool
+}
+
+// overlapPolygons - площадь пересечения полигонов a и b. Граница пересечения состоит из участков границы a,
+// лежащих внутри b, и участков границы b, лежащих внутри a. Рёбра делятся на участки точками пересечения
+// с рёбрами другого полигона, вхождение участка определяется по его середине.
+func overlapPolygons(a, b orb.MultiPolygon) overlap {
+	a, b = orient(a), orient(b)
+
+	var res overlap
+
+	inside, outside, area := boundaryInside(a, b, true)
+	res.aInB, res.aOutB = inside, outside
+	res.area += area
+
+	inside, outside, area = boundaryInside(b, a, false)
+	res.bInA, res.bOutA = inside, outside
+	res.area += area
+
+	res.area = math.Max(res.area, 0)
+
+	return res
+}
+
+// boundaryInside - обход участков границы a относительно полигонов b. Возвращает признаки наличия участков
+// внутри и вне b и интеграл площади по участкам внутри b. Участки, совпадающие с границей b, считаются
+// лежащими внутри, если границы направлены одинаково, и входят в интеграл, только если задан shared,
+// чтобы при обходе обоих полигонов общий участок границы учитывался один раз.
+func boundaryInside(a, b orb.MultiPolygon, shared bool) (inside, outside bool, integral float64) {
+	bBound := b.Bound()
+
+	for _, polygon := range a {
+		for _, r := range polygon {
+			for e := 0; e < len(r)-1; e++ {
+				ts := []float64{0, 1}
+
+				var coincident []orb.LineString
+
+				if (orb.MultiPoint{r[e], r[e+1]}).Bound().Intersects(bBound) {
+					var crossings []float64
+
+					crossings, coincident = edgeCrossings(r[e], r[e+1], b)
+					ts = append(ts, crossings...)
+					sort.Float64s(ts)
+				}
+
+				for k := 0; k < len(ts)-1; k++ {
+					if ts[k+1]-ts[k] < minPieceLength {
+						continue
+					}
+
+					from, to := interpolate(r[e], r[e+1], ts[k]), interpolate(r[e], r[e+1], ts[k+1])
+					mid := interpolate(from, to, 0.5) // nolint:gomnd // середина
+
+					if on, same := onBoundary(mid, from, to, coincident); on {
+						if same {
+							inside = true
+
+							if shared {
+								integral += segmentIntegral(from, to)
+							}
+						}
+
+						continue
+					}
+
+					if !planar.MultiPolygonContains(b, mid) {
+						outside = true
+
+						continue
+					}
+
+					inside = true
+					integral += segmentIntegral(from, to)
+				}
+			}
+		}
+	}
+
+	return inside, outside, integral
+}
+
+// onBoundary - точка p участка from-to лежит на одном из рёбер edges, same - ребро направлено так же, как участок.
+func onBoundary(p, from, to orb.Point, edges []orb.LineString) (on, same bool) {
+	dir := orb.Point{to[0] - from[0], to[1] - from[1]}
+
+	for _, edge := range edges {
+		if planar.DistanceFromSegmentSquared(edge[0], edge[1], p) > boundaryEpsilon*boundaryEpsilon {
+			continue
+		}
+
+		return true, dot(dir, orb.Point{edge[1][0] - edge[0][0], edge[1][1] - edge[0][1]}) > 0
+	}
+
+	return false, false
+}
+
+const (
+	// boundaryEpsilon - расстояние до ребра в градусах, на котором точка считается лежащей на нём.
+	boundaryEpsilon = 1e-10
+	// minPieceLength - минимальная длина участка ребра в долях ребра. Более короткие участки
+	// возникают при прохождении ребра через вершину другого полигона.
+	minPieceLength = 1e-12
+)
+
+// edgeCrossings - параметры точек пересечения ребра ab с рёбрами полигонов и рёбра, лежащие с ним на одной прямой.
+// Для таких рёбер возвращаются параметры обоих концов перекрытия.
+func edgeCrossings(a, b orb.Point, members orb.MultiPolygon) (ts []float64, coincident []orb.LineString) {
+	edge := orb.MultiPoint{a, b}.Bound()
+	r := orb.Point{b[0] - a[0], b[1] - a[1]}
+
+	for _, polygon := range members {
+		for _, ring := range polygon {
+			for e := 0; e < len(ring)-1; e++ {
+				c, d := ring[e], ring[e+1]
+				if !edge.Intersects(orb.MultiPoint{c, d}.Bound()) {
+					continue
+				}
+
+				t, _, ok := SegmentIntersection(a, b, c, d)
+				if !ok {
+					continue
+				}
+
+				ts = append(ts, t)
+
+				if s := (orb.Point{d[0] - c[0], d[1] - c[1]}); cross(r, s) == 0 {
+					coincident = append(coincident, orb.LineString{c, d})
+					rr := dot(r, r)
+					ts = append(ts,
+						clamp(dot(orb.Point{c[0] - a[0], c[1] - a[1]}, r)/rr),
+						clamp(dot(orb.Point{d[0] - a[0], d[1] - a[1]}, r)/rr))
+				}
+			}
+		}
+	}
+
+	return ts, coincident
+}
+
+// clamp - ограничение параметра отрезком [0, 1].
+func clamp(t float64) float64 {
+	return math.Min(math.Max(t, 0), 1)
+}
+
+// ringIntegral - интеграл площади по кольцу, положительный для кольца, ориентированного против часовой стрелки.
+func ringIntegral(r orb.Ring) float64 {
+	sum := 0.0
+	for i := 0; i < len(r)-1; i++ {
+		sum += segmentIntegral(r[i], r[i+1])
+	}
+
+	return sum
+}
+
+// segmentIntegral - интеграл -R² ∫ sin(lat) d(lon) вдоль отрезка ab.
+func segmentIntegral(a, b orb.Point) float64 {
+	dLon := deg2rad(b.Lon() - a.Lon())
+	lat1, lat2 := deg2rad(a.Lat()), deg2rad(b.Lat())
+
+	var mean float64
+	if dLat := lat2 - lat1; math.Abs(dLat) > 1e-12 {
+		mean = (math.Cos(lat1) - math.Cos(lat2)) / dLat
+	} else {
+		mean = math.Sin(lat1)
+	}
+
+	return -orb.EarthRadius * orb.EarthRadius * dLon * mean
+}
+
+// orient - полигоны с внешними кольцами против часовой стрелки и дырами по часовой.
+func orient(members orb.MultiPolygon) orb.MultiPolygon {
+	res := make(orb.MultiPolygon, len(members))
+
+	for i, polygon := range members {
+		res[i] = make(orb.Polygon, len(polygon))
+
+		for j, r := range polygon {
+			want := orb.CCW
+			if j > 0 {
+				want = orb.CW
+			}
+
+			if r.Orientation() != want {
+				r = r.Clone()
+				r.Reverse()
+			}
+
+			res[i][j] = r
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,38 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// BoundAround - прямоугольник, гарантированно содержащий все точки на расстоянии не более radius метров
+// от точки p. Долгота границ может выходить за пределы [-180, 180].
+func BoundAround(p orb.Point, radius float64) orb.Bound {
+	// угловое расстояние
+	delta := radius / orb.EarthRadius
+	lat := deg2rad(p.Lat())
+
+	minLat, maxLat := lat-delta, lat+delta
+	dLon := math.Pi
+
+	// если в окружность не попадает полюс, то наибольшее отклонение по долготе
+	// достигается в точке касания меридиана
+	if minLat > -math.Pi/2 && maxLat < math.Pi/2 {
+		if s := math.Sin(delta) / math.Cos(lat); s < 1 {
+			dLon = math.Asin(s)
+		}
+	}
+
+	minLat = math.Max(minLat, -math.Pi/2)
+	maxLat = math.Min(maxLat, math.Pi/2)
+
+	return orb.Bound{
+		Min: orb.Point{p.Lon() - rad2deg(dLon), rad2deg(minLat)},
+		Max: orb.Point{p.Lon() + rad2deg(dLon), rad2deg(maxLat)},
+	}
+}
+
+func rad2deg(r float64) float64 {
+	return r * 180.0 / math.Pi
+}
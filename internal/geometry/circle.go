@@ -0,0 +1,69 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+	orbgeo "github.com/paulmach/orb/geo"
+)
+
+// Circle - круг на поверхности земли: все точки не дальше Radius метров от центра.
+type Circle struct {
+	Center orb.Point
+	// Radius - радиус, в метрах
+	Radius float64
+}
+
+func (c Circle) Bounds() []orb.Bound {
+	return []orb.Bound{BoundAround(c.Center, c.Radius)}
+}
+
+func (c Circle) Contains(p orb.Point) bool {
+	return orbgeo.DistanceHaversine(c.Center, p) <= c.Radius
+}
+
+func (c Circle) MayContain(_ int, p orb.Point) bool {
+	return c.Contains(p)
+}
+
+// Nearest - ближайшая точка окружности лежит на луче из центра круга через точку.
+func (c Circle) Nearest(p orb.Point) Nearest {
+	return Nearest{
+		Distance: math.Abs(orbgeo.DistanceHaversine(c.Center, p) - c.Radius),
+		Point:    NormalizePoint(orbgeo.PointAtBearingAndDistance(c.Center, orbgeo.Bearing(c.Center, p), c.Radius)),
+		Ring:     0,
+		Edge:     0,
+	}
+}
+
+// Crossings - пересечения отрезка с окружностью в локальной равнопромежуточной проекции с центром в центре круга.
+func (c Circle) Crossings(a, b orb.Point) []float64 {
+	scale := deg2rad(1) * orb.EarthRadius
+	cos := math.Cos(deg2rad(c.Center.Lat()))
+
+	// координаты концов отрезка относительно центра, в метрах
+	ax := (UnwrapSegment(c.Center, a)[0] - c.Center.Lon()) * cos * scale
+	ay := (a.Lat() - c.Center.Lat()) * scale
+	dx := (b[0] - a[0]) * cos * scale
+	dy := (b[1] - a[1]) * scale
+
+	// |a + t*d| = Radius
+	qa := dx*dx + dy*dy
+	qb := 2 * (ax*dx + ay*dy) // nolint:gomnd // коэффициент квадратного уравнения
+	qc := ax*ax + ay*ay - c.Radius*c.Radius
+
+	discriminant := qb*qb - 4*qa*qc // nolint:gomnd // дискриминант
+	if qa == 0 || discriminant < 0 {
+		return nil
+	}
+
+	var res []float64
+
+	for _, sign := range []float64{-1, 1} {
+		if t := (-qb + sign*math.Sqrt(discriminant)) / (2 * qa); t >= 0 && t <= 1 { // nolint:gomnd // корень
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,140 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// масштаб хранения координат: 1e-7 градуса, около 1 см
+const compactScale = 1e7
+
+// compactRing - кольцо в компактном виде: первая вершина хранится целыми числами в единицах 1e-7 градуса,
+// остальные - смещениями int32 относительно предыдущей вершины. Занимает вдвое меньше памяти, чем orb.Ring.
+type compactRing struct {
+	startX, startY int64
+	deltas         []int32
+}
+
+// compactPolygon - полигон в компактном виде.
+type compactPolygon []compactRing
+
+func newCompactRing(r orb.Ring) compactRing {
+	c := compactRing{deltas: make([]int32, 0, 2*len(r))}
+	if len(r) == 0 {
+		return c
+	}
+
+	prevX, prevY := quantize(r[0][0]), quantize(r[0][1])
+	c.startX, c.startY = prevX, prevY
+
+	for i := 1; i < len(r); i++ {
+		x, y := quantize(r[i][0]), quantize(r[i][1])
+		c.deltas = append(c.deltas, int32(x-prevX), int32(y-prevY))
+		prevX, prevY = x, y
+	}
+
+	return c
+}
+
+func (c compactRing) ring() orb.Ring {
+	r := make(orb.Ring, 0, len(c.deltas)/2+1)
+	x, y := c.startX, c.startY
+	r = append(r, orb.Point{float64(x) / compactScale, float64(y) / compactScale})
+
+	for i := 0; i+1 < len(c.deltas); i += 2 {
+		x += int64(c.deltas[i])
+		y += int64(c.deltas[i+1])
+		r = append(r, orb.Point{float64(x) / compactScale, float64(y) / compactScale})
+	}
+
+	return r
+}
+
+// edges - обход рёбер кольца по порядку без восстановления всего кольца: вершины восстанавливаются
+// по ходу обхода. Обход прекращается, если f возвращает false.
+func (c compactRing) edges(f func(edge int, a, b orb.Point) bool) {
+	x, y := c.startX, c.startY
+	prev := orb.Point{float64(x) / compactScale, float64(y) / compactScale}
+
+	for i := 0; i+1 < len(c.deltas); i += 2 {
+		x += int64(c.deltas[i])
+		y += int64(c.deltas[i+1])
+		cur := orb.Point{float64(x) / compactScale, float64(y) / compactScale}
+
+		if !f(i/2, prev, cur) { // nolint:gomnd // две координаты на вершину
+			return
+		}
+
+		prev = cur
+	}
+}
+
+// contains - вхождение точки в кольцо по правилу чет-нечет. Точки границы считаются входящими,
+// так же как в planar.RingContains.
+func (c compactRing) contains(p orb.Point) bool {
+	inside, on := false, false
+
+	c.edges(func(_ int, a, b orb.Point) bool {
+		if onSegment(p, a, b) {
+			on = true
+
+			return false
+		}
+
+		if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < a[0]+(p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1]) {
+			inside = !inside
+		}
+
+		return true
+	})
+
+	return on || inside
+}
+
+// onSegment - точка лежит на отрезке ab.
+func onSegment(p, a, b orb.Point) bool {
+	if cross(orb.Point{b[0] - a[0], b[1] - a[1]}, orb.Point{p[0] - a[0], p[1] - a[1]}) != 0 {
+		return false
+	}
+
+	return p[0] >= math.Min(a[0], b[0]) && p[0] <= math.Max(a[0], b[0]) &&
+		p[1] >= math.Min(a[1], b[1]) && p[1] <= math.Max(a[1], b[1])
+}
+
+func newCompactPolygon(p orb.Polygon) compactPolygon {
+	c := make(compactPolygon, 0, len(p))
+	for i := 0; i < len(p); i++ {
+		c = append(c, newCompactRing(p[i]))
+	}
+
+	return c
+}
+
+func (c compactPolygon) polygon() orb.Polygon {
+	p := make(orb.Polygon, 0, len(c))
+	for i := 0; i < len(c); i++ {
+		p = append(p, c[i].ring())
+	}
+
+	return p
+}
+
+// contains - вхождение точки в полигон: во внешнее кольцо и ни в одну из дыр.
+func (c compactPolygon) contains(p orb.Point) bool {
+	if len(c) == 0 || !c[0].contains(p) {
+		return false
+	}
+
+	for i := 1; i < len(c); i++ {
+		if c[i].contains(p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func quantize(v float64) int64 {
+	return int64(math.Round(v * compactScale))
+}
@@ -0,0 +1,234 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+	orbgeo "github.com/paulmach/orb/geo"
+)
+
+// Route - геометрия, построенная вдоль маршрута.
+type Route interface {
+	// Locate - расстояние от точки до линии маршрута и пройденное вдоль маршрута расстояние
+	// до ближайшей к точке точки маршрута, в метрах
+	Locate(p orb.Point) (distance, progress float64)
+}
+
+// minCrossingInterval - участки отрезка короче этой доли длины (касания границы) не считаются пересечениями.
+const minCrossingInterval = 1e-9
+
+// Corridor - коридор заданной ширины вдоль маршрута. Каждый отрезок маршрута является отдельной частью геометрии.
+type Corridor struct {
+	Route orb.LineString
+	// Width - ширина коридора, в метрах. Коридор отстоит от линии маршрута на половину ширины в каждую сторону
+	Width float64
+	// пройденное расстояние до начала каждого отрезка маршрута
+	offsets []float64
+	// прямоугольники отрезков маршрута, расширенные на половину ширины коридора
+	bounds []orb.Bound
+}
+
+// NewCorridor - коридор шириной width метров вдоль маршрута route.
+// Маршрут, пересекающий антимеридиан, хранится с непрерывной долготой.
+func NewCorridor(route orb.LineString, width float64) *Corridor {
+	route = unwrapLine(route)
+
+	offsets := make([]float64, len(route))
+	for i := 1; i < len(route); i++ {
+		offsets[i] = offsets[i-1] + orbgeo.DistanceHaversine(route[i-1], route[i])
+	}
+
+	c := &Corridor{Route: route, Width: width, offsets: offsets}
+
+	c.bounds = make([]orb.Bound, 0, len(route))
+	for i := 0; i < len(route)-1; i++ {
+		c.bounds = append(c.bounds, BoundAround(route[i], c.halfWidth()).Union(BoundAround(route[i+1], c.halfWidth())))
+	}
+
+	return c
+}
+
+func (c *Corridor) halfWidth() float64 {
+	return c.Width / 2 // nolint:gomnd // половина ширины
+}
+
+// Bounds - прямоугольники отрезков маршрута, расширенные на половину ширины коридора.
+func (c *Corridor) Bounds() []orb.Bound {
+	return c.bounds
+}
+
+func (c *Corridor) Contains(p orb.Point) bool {
+	return NearestToLine(c.Route, p).Distance <= c.halfWidth()
+}
+
+func (c *Corridor) MayContain(part int, p orb.Point) bool {
+	if part+1 >= len(c.Route) {
+		return false
+	}
+
+	d, _ := DistanceToSegment(p, c.Route[part], c.Route[part+1])
+
+	return d <= c.halfWidth()
+}
+
+// Nearest - ближайшая точка границы коридора лежит на расстоянии половины ширины от ближайшей точки маршрута.
+func (c *Corridor) Nearest(p orb.Point) Nearest {
+	nearest := NearestToLine(c.Route, p)
+	if nearest.Edge < 0 {
+		return nearest
+	}
+
+	var bearing float64
+	if nearest.Distance > 0 {
+		bearing = orbgeo.Bearing(nearest.Point, p)
+	} else {
+		// точка на линии маршрута - граница перпендикулярна отрезку
+		bearing = orbgeo.Bearing(c.Route[nearest.Edge], c.Route[nearest.Edge+1]) + 90 // nolint:gomnd // перпендикуляр
+	}
+
+	return Nearest{
+		Distance: math.Abs(c.halfWidth() - nearest.Distance),
+		Point:    NormalizePoint(orbgeo.PointAtBearingAndDistance(nearest.Point, bearing, c.halfWidth())),
+		Ring:     0,
+		Edge:     nearest.Edge,
+	}
+}
+
+func (c *Corridor) Locate(p orb.Point) (distance, progress float64) {
+	nearest := NearestToLine(c.Route, p)
+	if nearest.Edge < 0 {
+		return nearest.Distance, 0
+	}
+
+	return nearest.Distance, c.offsets[nearest.Edge] + orbgeo.DistanceHaversine(c.Route[nearest.Edge], nearest.Point)
+}
+
+// Crossings - пересечения отрезка с границей коридора. Коридор - объединение выпуклых областей вокруг
+// отрезков маршрута (полоса и круги на концах), пересечение отрезка с каждой из них - один интервал
+// параметра t. Интервалы вычисляются в локальной равнопромежуточной проекции с центром в начале отрезка
+// маршрута, отрезки маршрута, прямоугольники которых не пересекают отрезок, пропускаются.
+// Границы объединения интервалов - точки пересечения.
+func (c *Corridor) Crossings(a, b orb.Point) []float64 {
+	intervals := make([][2]float64, 0, 1)
+
+	for i, bound := range c.bounds {
+		for _, shift := range segmentShifts(bound, a, b) {
+			lo, hi, ok := c.segmentInterval(i, orb.Point{a[0] + shift, a[1]}, orb.Point{b[0] + shift, b[1]})
+			if ok {
+				intervals = append(intervals, [2]float64{lo, hi})
+			}
+		}
+	}
+
+	return intervalBoundaries(intervals)
+}
+
+// segmentInterval - интервал параметра t из [0, 1], на котором точка a + t(b - a) находится не дальше
+// половины ширины коридора от отрезка маршрута part.
+func (c *Corridor) segmentInterval(part int, a, b orb.Point) (lo, hi float64, ok bool) {
+	scale := deg2rad(1) * orb.EarthRadius
+	start, end := c.Route[part], c.Route[part+1]
+	cos := math.Cos(deg2rad(start.Lat()))
+	h := c.halfWidth()
+
+	// координаты относительно начала отрезка маршрута, в метрах
+	px, py := (a[0]-start[0])*cos*scale, (a[1]-start[1])*scale
+	dx, dy := (b[0]-a[0])*cos*scale, (b[1]-a[1])*scale
+	ex, ey := (end[0]-start[0])*cos*scale, (end[1]-start[1])*scale
+
+	lo, hi = math.Inf(1), math.Inf(-1)
+
+	include := func(l, h float64, ok bool) {
+		if ok {
+			lo, hi = math.Min(lo, l), math.Max(hi, h)
+		}
+	}
+
+	include(discInterval(px, py, dx, dy, h))
+	include(discInterval(px-ex, py-ey, dx, dy, h))
+
+	if length := math.Hypot(ex, ey); length > 0 {
+		ux, uy := ex/length, ey/length
+
+		// полоса: проекция на отрезок маршрута в [0, length], поперечное расстояние в [-h, h]
+		alongLo, alongHi, alongOK := linearInterval(px*ux+py*uy, dx*ux+dy*uy, 0, length)
+		crossLo, crossHi, crossOK := linearInterval(py*ux-px*uy, dy*ux-dx*uy, -h, h)
+		stripLo, stripHi := math.Max(alongLo, crossLo), math.Min(alongHi, crossHi)
+
+		include(stripLo, stripHi, alongOK && crossOK && stripLo <= stripHi)
+	}
+
+	lo, hi = math.Max(lo, 0), math.Min(hi, 1)
+
+	return lo, hi, lo <= hi
+}
+
+// discInterval - интервал t, на котором |p + t*d| <= r.
+func discInterval(px, py, dx, dy, r float64) (lo, hi float64, ok bool) {
+	qa := dx*dx + dy*dy
+	qb := 2 * (px*dx + py*dy) // nolint:gomnd // коэффициент квадратного уравнения
+	qc := px*px + py*py - r*r
+
+	if qa == 0 {
+		return math.Inf(-1), math.Inf(1), qc <= 0
+	}
+
+	discriminant := qb*qb - 4*qa*qc // nolint:gomnd // дискриминант
+	if discriminant < 0 {
+		return 0, 0, false
+	}
+
+	root := math.Sqrt(discriminant)
+
+	return (-qb - root) / (2 * qa), (-qb + root) / (2 * qa), true // nolint:gomnd // корни
+}
+
+// linearInterval - интервал t, на котором min <= v + t*dv <= max.
+func linearInterval(v, dv, min, max float64) (lo, hi float64, ok bool) {
+	if dv == 0 {
+		return math.Inf(-1), math.Inf(1), v >= min && v <= max
+	}
+
+	lo, hi = (min-v)/dv, (max-v)/dv
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	return lo, hi, true
+}
+
+// intervalBoundaries - границы объединения интервалов внутри (0, 1).
+func intervalBoundaries(intervals [][2]float64) []float64 {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0] < intervals[j][0]
+	})
+
+	var merged [][2]float64
+
+	for _, interval := range intervals {
+		if last := len(merged) - 1; last >= 0 && interval[0] <= merged[last][1] {
+			merged[last][1] = math.Max(merged[last][1], interval[1])
+
+			continue
+		}
+
+		merged = append(merged, interval)
+	}
+
+	var res []float64
+
+	for _, interval := range merged {
+		if interval[1]-interval[0] < minCrossingInterval {
+			continue
+		}
+
+		for _, t := range interval {
+			if t > 0 && t < 1 {
+				res = append(res, t)
+			}
+		}
+	}
+
+	return res
+}
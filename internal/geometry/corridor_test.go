@@ -0,0 +1,54 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestCorridor_Locate(t *testing.T) {
+	// маршрут на восток вдоль экватора, затем на север
+	corridor := NewCorridor(orb.LineString{{0, 0}, {0.01, 0}, {0.01, 0.01}}, 100)
+
+	tests := []struct {
+		name         string
+		point        orb.Point
+		wantInside   bool
+		wantProgress float64
+	}{
+		{
+			name:         "on first segment",
+			point:        orb.Point{0.005, 0.0003},
+			wantInside:   true,
+			wantProgress: 556.6,
+		},
+		{
+			name:         "on second segment",
+			point:        orb.Point{0.0103, 0.005},
+			wantInside:   true,
+			wantProgress: 1113.2 + 556.6,
+		},
+		{
+			name:         "outside corridor",
+			point:        orb.Point{0.005, 0.001},
+			wantInside:   false,
+			wantProgress: 556.6,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := corridor.Contains(tt.point); got != tt.wantInside {
+				t.Errorf("Contains() got = %v, want %v", got, tt.wantInside)
+			}
+			if _, got := corridor.Locate(tt.point); math.Abs(got-tt.wantProgress) > 1 {
+				t.Errorf("Locate() progress = %f, want %f", got, tt.wantProgress)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestShape_Crossings(t *testing.T) {
+	square, _ := NewPolygons(orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+	antimeridian, _ := NewPolygons(orb.Polygon{{{179, 0}, {-179, 0}, {-179, 1}, {179, 1}, {179, 0}}})
+
+	tests := []struct {
+		name  string
+		shape Shape
+		a, b  orb.Point
+		want  []float64
+	}{
+		{
+			name:  "polygon through",
+			shape: square,
+			a:     orb.Point{-1, 0.5},
+			b:     orb.Point{2, 0.5},
+			want:  []float64{1.0 / 3, 2.0 / 3},
+		},
+		{
+			name:  "polygon miss",
+			shape: square,
+			a:     orb.Point{-1, 2},
+			b:     orb.Point{2, 2},
+			want:  nil,
+		},
+		{
+			name:  "polygon across antimeridian",
+			shape: antimeridian,
+			a:     orb.Point{178, 0.5},
+			b:     UnwrapSegment(orb.Point{178, 0.5}, orb.Point{-178, 0.5}),
+			want:  []float64{0.25, 0.75},
+		},
+		{
+			name:  "circle through center",
+			shape: Circle{Center: orb.Point{0, 0}, Radius: 1113.2},
+			a:     orb.Point{-0.02, 0},
+			b:     orb.Point{0.02, 0},
+			want:  []float64{0.25, 0.75},
+		},
+		{
+			name:  "corridor across",
+			shape: NewCorridor(orb.LineString{{0, -1}, {0, 1}}, 2226.4),
+			a:     orb.Point{-0.02, 0},
+			b:     orb.Point{0.02, 0},
+			want:  []float64{0.25, 0.75},
+		},
+		{
+			// хорда около 300 м, короче шага проверки в четверть ширины коридора
+			name:  "corridor graze near route end",
+			shape: NewCorridor(orb.LineString{{0, 0}, {0, 1}}, 2226.4),
+			a:     orb.Point{-0.5, -0.0099},
+			b:     orb.Point{0.5, -0.0099},
+			want:  []float64{0.5 - 0.00141, 0.5 + 0.00141},
+		},
+		{
+			name:  "corridor along two route segments",
+			shape: NewCorridor(orb.LineString{{0, 0}, {0.1, 0}, {0.1, 0.1}}, 2226.4),
+			a:     orb.Point{-0.05, 0},
+			b:     orb.Point{0.2, 0},
+			want:  []float64{0.16, 0.64},
+		},
+		{
+			name:  "corridor across antimeridian",
+			shape: NewCorridor(orb.LineString{{179.9, -1}, {-179.9, 1}}, 2226.4),
+			a:     orb.Point{-179, 0},
+			b:     orb.Point{-179.1, 0},
+			want:  nil,
+		},
+		{
+			name:  "corridor across antimeridian through",
+			shape: NewCorridor(orb.LineString{{179.9, -1}, {-179.9, 1}}, 2226.4),
+			a:     orb.Point{-179.98, 0},
+			b:     UnwrapSegment(orb.Point{-179.98, 0}, orb.Point{179.98, 0}),
+			// маршрут наклонен, поэтому коридор вдоль параллели немного шире половины отрезка
+			want: []float64{0.2487, 0.7513},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.shape.Crossings(tt.a, tt.b)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Crossings() got = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-3 {
+					t.Errorf("Crossings() got = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package geometry - геодезические вычисления над геометриями геозон.
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+	orbgeo "github.com/paulmach/orb/geo"
+)
+
+// Nearest - ближайшая к точке точка границы геометрии.
+type Nearest struct {
+	// Distance - геодезическое расстояние до границы, в метрах
+	Distance float64
+	// Point - ближайшая точка границы
+	Point orb.Point
+	// Member - индекс полигона мультиполигона, на границе которого лежит ближайшая точка
+	Member int
+	// Ring - индекс кольца полигона, на котором лежит ближайшая точка, 0 - внешнее кольцо
+	Ring int
+	// Edge - индекс ребра в кольце
+	Edge int
+}
+
+// DistanceToSegment - геодезическое расстояние в метрах от точки p до отрезка ab большого круга
+// и ближайшая к p точка отрезка.
+func DistanceToSegment(p, a, b orb.Point) (float64, orb.Point) {
+	d12 := orbgeo.DistanceHaversine(a, b) / orb.EarthRadius
+	if d12 == 0 {
+		return orbgeo.DistanceHaversine(a, p), a
+	}
+
+	d13 := orbgeo.DistanceHaversine(a, p) / orb.EarthRadius
+	bearing12 := orbgeo.Bearing(a, b)
+	delta := deg2rad(orbgeo.Bearing(a, p) - bearing12)
+
+	// расстояние вдоль дуги ab до проекции точки p
+	alongTrack := math.Atan2(math.Sin(d13)*math.Cos(delta), math.Cos(d13))
+
+	switch {
+	case alongTrack <= 0:
+		return d13 * orb.EarthRadius, a
+	case alongTrack >= d12:
+		return orbgeo.DistanceHaversine(b, p), b
+	}
+
+	// поперечное расстояние от точки p до большого круга ab
+	crossTrack := math.Asin(math.Sin(d13) * math.Sin(delta))
+
+	return math.Abs(crossTrack) * orb.EarthRadius, orbgeo.PointAtBearingAndDistance(a, bearing12, alongTrack*orb.EarthRadius)
+}
+
+// NearestToLine - ближайшая к точке точка линии.
+func NearestToLine(ls orb.LineString, p orb.Point) Nearest {
+	nearest := Nearest{Distance: math.Inf(1), Ring: 0, Edge: -1}
+
+	for e := 0; e < len(ls)-1; e++ {
+		if d, point := DistanceToSegment(p, ls[e], ls[e+1]); d < nearest.Distance {
+			nearest = Nearest{Distance: d, Point: point, Ring: 0, Edge: e}
+		}
+	}
+
+	return nearest
+}
+
+// NearestToPolygon - ближайшая к точке точка на любом ребре любого кольца полигона (внешнего и дыр).
+func NearestToPolygon(polygon orb.Polygon, p orb.Point) Nearest {
+	nearest := Nearest{Distance: math.Inf(1), Ring: -1, Edge: -1}
+
+	for r := 0; r < len(polygon); r++ {
+		ring := polygon[r]
+
+		for e := 0; e < len(ring)-1; e++ {
+			if d, point := DistanceToSegment(p, ring[e], ring[e+1]); d < nearest.Distance {
+				nearest = Nearest{Distance: d, Point: point, Ring: r, Edge: e}
+			}
+		}
+	}
+
+	return nearest
+}
+
+// NearestToPolygons - ближайшая к точке точка границы мультиполигона.
+func NearestToPolygons(members orb.MultiPolygon, p orb.Point) Nearest {
+	nearest := Nearest{Distance: math.Inf(1), Member: -1, Ring: -1, Edge: -1}
+
+	for i := 0; i < len(members); i++ {
+		if n := NearestToPolygon(members[i], p); n.Distance < nearest.Distance {
+			n.Member = i
+			nearest = n
+		}
+	}
+
+	return nearest
+}
+
+func deg2rad(d float64) float64 {
+	return d * math.Pi / 180.0
+}
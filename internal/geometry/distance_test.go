@@ -0,0 +1,132 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestDistanceToSegment(t *testing.T) {
+	type args struct {
+		point orb.Point
+		a     orb.Point
+		b     orb.Point
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      float64
+		tolerance float64
+	}{
+		{
+			name: "middle of long edge",
+			args: args{
+				point: orb.Point{39.7, 47.2027},
+				a:     orb.Point{39.666, 47.2},
+				b:     orb.Point{39.734, 47.2},
+			},
+			want:      300,
+			tolerance: 1,
+		},
+		{
+			name: "before segment start",
+			args: args{
+				point: orb.Point{39.65, 47.2},
+				a:     orb.Point{39.666, 47.2},
+				b:     orb.Point{39.734, 47.2},
+			},
+			want:      1209,
+			tolerance: 2,
+		},
+		{
+			name: "degenerate segment",
+			args: args{
+				point: orb.Point{0, 1},
+				a:     orb.Point{0, 0},
+				b:     orb.Point{0, 0},
+			},
+			want:      111319,
+			tolerance: 1,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, _ := DistanceToSegment(tt.args.point, tt.args.a, tt.args.b)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("DistanceToSegment() got = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNearestToPolygon(t *testing.T) {
+	polygon := orb.Polygon{
+		{{0, 0}, {0.1, 0}, {0.1, 0.1}, {0, 0.1}, {0, 0}},
+		{{0.04, 0.04}, {0.04, 0.06}, {0.06, 0.06}, {0.06, 0.04}, {0.04, 0.04}},
+	}
+
+	tests := []struct {
+		name     string
+		point    orb.Point
+		wantRing int
+		want     float64
+	}{
+		{
+			name:     "closer to outer ring",
+			point:    orb.Point{0.01, 0.05},
+			wantRing: 0,
+			want:     1113,
+		},
+		{
+			name:     "closer to hole",
+			point:    orb.Point{0.035, 0.05},
+			wantRing: 1,
+			want:     556,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NearestToPolygon(polygon, tt.point)
+			if got.Ring != tt.wantRing {
+				t.Errorf("NearestToPolygon() ring = %d, want %d", got.Ring, tt.wantRing)
+			}
+			if math.Abs(got.Distance-tt.want) > 1 {
+				t.Errorf("NearestToPolygon() distance = %f, want %f", got.Distance, tt.want)
+			}
+		})
+	}
+}
+
+func TestNearestToPolygons(t *testing.T) {
+	t.Parallel()
+
+	members := orb.MultiPolygon{
+		{{{0, 0}, {0.1, 0}, {0.1, 0.1}, {0, 0.1}, {0, 0}}},
+		{
+			{{1, 0}, {1.1, 0}, {1.1, 0.1}, {1, 0.1}, {1, 0}},
+			{{1.04, 0.04}, {1.04, 0.06}, {1.06, 0.06}, {1.06, 0.04}, {1.04, 0.04}},
+		},
+	}
+
+	got := NearestToPolygons(members, orb.Point{1.05, 0.035})
+
+	if got.Member != 1 || got.Ring != 1 || got.Edge != 3 {
+		t.Errorf("NearestToPolygons() member = %d, ring = %d, edge = %d, want 1, 1, 3", got.Member, got.Ring, got.Edge)
+	}
+
+	if math.Abs(got.Point.Lon()-1.05) > 1e-6 || math.Abs(got.Point.Lat()-0.04) > 1e-6 {
+		t.Errorf("NearestToPolygons() point = %v, want [1.05 0.04]", got.Point)
+	}
+}
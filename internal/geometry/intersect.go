@@ -0,0 +1,156 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+)
+
+// Intersection - пересечение двух рёбер колец полигона.
+type Intersection struct {
+	RingA, EdgeA int
+	RingB, EdgeB int
+	Point        orb.Point
+}
+
+// SegmentIntersection - пересечение отрезков ab и cd на плоскости. Возвращает параметры точки пересечения
+// вдоль каждого из отрезков (0 - начало, 1 - конец). Для коллинеарных перекрывающихся отрезков
+// возвращается начало перекрытия на отрезке ab.
+func SegmentIntersection(a, b, c, d orb.Point) (t, u float64, ok bool) {
+	r := orb.Point{b[0] - a[0], b[1] - a[1]}
+	s := orb.Point{d[0] - c[0], d[1] - c[1]}
+	ca := orb.Point{c[0] - a[0], c[1] - a[1]}
+
+	denom := cross(r, s)
+	if denom == 0 {
+		return collinearIntersection(r, s, ca)
+	}
+
+	t = cross(ca, s) / denom
+	u = cross(ca, r) / denom
+
+	return t, u, t >= 0 && t <= 1 && u >= 0 && u <= 1
+}
+
+// collinearIntersection - перекрытие параллельных отрезков, лежащих на одной прямой.
+func collinearIntersection(r, s, ca orb.Point) (t, u float64, ok bool) {
+	rr := dot(r, r)
+	if cross(ca, r) != 0 || rr == 0 {
+		return 0, 0, false
+	}
+
+	t0 := dot(ca, r) / rr
+	t1 := t0 + dot(s, r)/rr
+
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+
+	if t1 < 0 || t0 > 1 {
+		return 0, 0, false
+	}
+
+	t = math.Max(t0, 0)
+
+	// параметр той же точки вдоль второго отрезка
+	if ss := dot(s, s); ss != 0 {
+		u = (t*dot(r, s) - dot(ca, s)) / ss
+	}
+
+	return t, u, true
+}
+
+// PolygonIntersections - пересечения рёбер колец полигона: самопересечения колец и пересечения колец
+// между собой. Соседние рёбра одного кольца, имеющие общую вершину, пересечением не считаются.
+// Поиск прекращается, если найдено limit пересечений (0 - без ограничения).
+func PolygonIntersections(p orb.Polygon, limit int) []Intersection {
+	type segment struct {
+		ring, edge int
+		bound      orb.Bound
+	}
+
+	segments := make([]segment, 0)
+
+	for r := 0; r < len(p); r++ {
+		for e := 0; e < len(p[r])-1; e++ {
+			segments = append(segments, segment{ring: r, edge: e, bound: orb.MultiPoint{p[r][e], p[r][e+1]}.Bound()})
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].bound.Min[0] < segments[j].bound.Min[0]
+	})
+
+	var res []Intersection
+
+	active := make([]segment, 0)
+
+	for _, cur := range segments {
+		// выметаем рёбра, лежащие левее текущего
+		n := 0
+
+		for _, a := range active {
+			if a.bound.Max[0] >= cur.bound.Min[0] {
+				active[n] = a
+				n++
+			}
+		}
+
+		active = active[:n]
+
+		for _, a := range active {
+			if !a.bound.Intersects(cur.bound) || adjacent(p, a.ring, a.edge, cur.ring, cur.edge) {
+				continue
+			}
+
+			ra, rb := p[a.ring], p[cur.ring]
+			if t, _, ok := SegmentIntersection(ra[a.edge], ra[a.edge+1], rb[cur.edge], rb[cur.edge+1]); ok {
+				res = append(res, Intersection{
+					RingA: a.ring, EdgeA: a.edge,
+					RingB: cur.ring, EdgeB: cur.edge,
+					Point: interpolate(ra[a.edge], ra[a.edge+1], t),
+				})
+
+				if limit > 0 && len(res) >= limit {
+					return res
+				}
+			}
+		}
+
+		active = append(active, cur)
+	}
+
+	return res
+}
+
+// adjacent - рёбра одного кольца с общей вершиной.
+func adjacent(p orb.Polygon, ringA, edgeA, ringB, edgeB int) bool {
+	if ringA != ringB {
+		return false
+	}
+
+	last := len(p[ringA]) - 2 // nolint:gomnd // индекс последнего ребра замкнутого кольца
+
+	switch {
+	case edgeA-edgeB == 1 || edgeB-edgeA == 1:
+		return true
+	case p[ringA][0] == p[ringA][last+1]:
+		return (edgeA == 0 && edgeB == last) || (edgeB == 0 && edgeA == last)
+	default:
+		return false
+	}
+}
+
+// interpolate - точка на отрезке ab с параметром t.
+func interpolate(a, b orb.Point, t float64) orb.Point {
+	return orb.Point{a[0] + (b[0]-a[0])*t, a[1] + (b[1]-a[1])*t}
+}
+
+func cross(a, b orb.Point) float64 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+func dot(a, b orb.Point) float64 {
+	return a[0]*b[0] + a[1]*b[1]
+}
@@ -0,0 +1,67 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+	orbgeo "github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/planar"
+)
+
+// Measures - геодезические характеристики геометрии геозоны: площадь в квадратных метрах, периметр в метрах,
+// центр масс и описывающий прямоугольник. Долгота прямоугольника может выходить за пределы [-180, 180],
+// если геометрия пересекает антимеридиан.
+type Measures struct {
+	Area      float64
+	Perimeter float64
+	Centroid  orb.Point
+	Bound     orb.Bound
+}
+
+// Geometry - полигоны геозоны наибольшего сохраненного разрешения.
+func (p *Polygons) Geometry() orb.MultiPolygon {
+	if p.full != nil {
+		return p.fullMembers()
+	}
+
+	return p.Members
+}
+
+// Measure - площадь и периметр полигонов с учетом дыр, центр масс на плоскости долгота-широта.
+func (p *Polygons) Measure() Measures {
+	members := p.Geometry()
+	centroid, _ := planar.CentroidArea(members)
+
+	return Measures{
+		Area:      Area(members),
+		Perimeter: orbgeo.LengthHaversign(members),
+		Centroid:  NormalizePoint(centroid),
+		Bound:     members.Bound(),
+	}
+}
+
+// Measure - площадь и длина окружности сферического круга.
+func (c Circle) Measure() Measures {
+	angle := c.Radius / orb.EarthRadius
+
+	return Measures{
+		Area:      2 * math.Pi * orb.EarthRadius * orb.EarthRadius * (1 - math.Cos(angle)), // nolint:gomnd // площадь сферического сегмента
+		Perimeter: 2 * math.Pi * orb.EarthRadius * math.Sin(angle),                         // nolint:gomnd // длина окружности
+		Centroid:  c.Center,
+		Bound:     c.Bounds()[0],
+	}
+}
+
+// Measure - площадь и периметр коридора без учета перекрытия на изгибах маршрута:
+// прямоугольник вдоль маршрута и полукруги на его концах. Центр масс - центр линии маршрута.
+func (c *Corridor) Measure() Measures {
+	length := c.offsets[len(c.offsets)-1]
+	centroid, _ := planar.CentroidArea(c.Route)
+
+	return Measures{
+		Area:      length*c.Width + math.Pi*c.halfWidth()*c.halfWidth(),
+		Perimeter: 2*length + math.Pi*c.Width, // nolint:gomnd // две стороны коридора
+		Centroid:  NormalizePoint(centroid),
+		Bound:     union(c.Bounds()),
+	}
+}
@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestShape_Measure(t *testing.T) {
+	// 1° дуги на экваторе, в метрах
+	degree := deg2rad(1) * orb.EarthRadius
+
+	square, _ := NewPolygons(orb.Polygon{{{0, 0}, {0.01, 0}, {0.01, 0.01}, {0, 0.01}, {0, 0}}})
+
+	tests := []struct {
+		name          string
+		shape         Shape
+		wantArea      float64
+		wantPerimeter float64
+		wantCentroid  orb.Point
+	}{
+		{
+			name:          "polygon",
+			shape:         square,
+			wantArea:      0.01 * degree * 0.01 * degree,
+			wantPerimeter: 0.04 * degree,
+			wantCentroid:  orb.Point{0.005, 0.005},
+		},
+		{
+			name:          "circle",
+			shape:         Circle{Center: orb.Point{10, 20}, Radius: 1000},
+			wantArea:      math.Pi * 1000 * 1000,
+			wantPerimeter: 2 * math.Pi * 1000,
+			wantCentroid:  orb.Point{10, 20},
+		},
+		{
+			name:          "corridor",
+			shape:         NewCorridor(orb.LineString{{0, 0}, {0, 0.01}}, 100),
+			wantArea:      0.01*degree*100 + math.Pi*50*50,
+			wantPerimeter: 0.02*degree + math.Pi*100,
+			wantCentroid:  orb.Point{0, 0.005},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.shape.Measure()
+			if math.Abs(got.Area-tt.wantArea) > tt.wantArea*1e-3 {
+				t.Errorf("Measure() area = %v, want %v", got.Area, tt.wantArea)
+			}
+			if math.Abs(got.Perimeter-tt.wantPerimeter) > tt.wantPerimeter*1e-3 {
+				t.Errorf("Measure() perimeter = %v, want %v", got.Perimeter, tt.wantPerimeter)
+			}
+			if planarDistance(got.Centroid, tt.wantCentroid) > 1e-9 {
+				t.Errorf("Measure() centroid = %v, want %v", got.Centroid, tt.wantCentroid)
+			}
+			if !got.Bound.Contains(tt.wantCentroid) {
+				t.Errorf("Measure() bound = %v does not contain centroid", got.Bound)
+			}
+		})
+	}
+}
+
+func planarDistance(a, b orb.Point) float64 {
+	return math.Hypot(a[0]-b[0], a[1]-b[1])
+}
@@ -0,0 +1,227 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+	orbgeo "github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/planar"
+)
+
+// Relation - отношение полигона запроса к геометрии геозоны.
+type Relation int
+
+const (
+	// RelationDisjoint - полигон и геозона не пересекаются.
+	RelationDisjoint Relation = iota
+	// RelationIntersects - полигон и геозона частично перекрываются.
+	RelationIntersects
+	// RelationContains - геозона целиком лежит внутри полигона.
+	RelationContains
+	// RelationWithin - полигон целиком лежит внутри геозоны.
+	RelationWithin
+)
+
+// circleSegments - количество вершин многоугольника, которым приближается круг.
+const circleSegments = 128
+
+// Relate - отношение полигонов query к геометрии геозоны и площадь их пересечения в квадратных метрах.
+// Круг приближается вписанным многоугольником. Для коридора площадь пересечения равна длине участков
+// маршрута внутри полигона, умноженной на ширину коридора.
+func Relate(query *Polygons, shape Shape) (Relation, float64) {
+	switch s := shape.(type) {
+	case *Polygons:
+		members, bounds := s.Members, s.bounds
+		if s.full != nil {
+			members, bounds = s.fullMembers(), s.fullBounds
+		}
+
+		return relatePolygons(align(query.Members, union(bounds)), members)
+	case Circle:
+		circle := orb.MultiPolygon{s.polygon()}
+
+		return relatePolygons(align(query.Members, circle.Bound()), circle)
+	case *Corridor:
+		return s.relate(align(query.Members, s.Route.Bound()))
+	default:
+		return RelationDisjoint, 0
+	}
+}
+
+// relatePolygons - отношение полигонов query к полигонам геозоны.
+func relatePolygons(query, members orb.MultiPolygon) (Relation, float64) {
+	o := overlapPolygons(query, members)
+
+	// граница полигона может целиком лежать внутри геозоны, когда полигон охватывает её дыру,
+	// поэтому полигон лежит внутри геозоны, только если внутри него нет дыр геозоны, и наоборот
+	switch {
+	case o.aInB && !o.aOutB && !holesInside(members, query):
+		return RelationWithin, o.area
+	case o.bInA && !o.bOutA && !holesInside(query, members):
+		return RelationContains, o.area
+	case o.aInB || o.bInA:
+		return RelationIntersects, o.area
+	default:
+		return RelationDisjoint, 0
+	}
+}
+
+// holesInside - часть границы дыры одного из полигонов a лежит внутри полигонов b.
+func holesInside(a, b orb.MultiPolygon) bool {
+	a, b = orient(a), orient(b)
+
+	holes := make(orb.MultiPolygon, 0)
+
+	for _, polygon := range a {
+		for j := 1; j < len(polygon); j++ {
+			holes = append(holes, orb.Polygon{polygon[j]})
+		}
+	}
+
+	if len(holes) == 0 {
+		return false
+	}
+
+	inside, _, _ := boundaryInside(holes, b, false)
+
+	return inside
+}
+
+// polygon - многоугольник, вписанный в круг.
+func (c Circle) polygon() orb.Polygon {
+	r := make(orb.Ring, 0, circleSegments+1)
+
+	for i := 0; i < circleSegments; i++ {
+		p := orbgeo.PointAtBearingAndDistance(c.Center, fullLon*float64(i)/circleSegments, c.Radius)
+		r = append(r, UnwrapSegment(c.Center, p))
+	}
+
+	return orb.Polygon{append(r, r[0])}
+}
+
+// relate - отношение полигонов query к коридору.
+func (c *Corridor) relate(query orb.MultiPolygon) (Relation, float64) {
+	length, inside, outside := c.routeInside(query)
+
+	// полигон внутри коридора: все вершины внутри коридора и рёбра не пересекают его границу
+	within, touches := true, false
+
+	for _, polygon := range query {
+		for _, r := range polygon {
+			for i := 0; i < len(r)-1; i++ {
+				if c.Contains(r[i]) {
+					touches = true
+				} else {
+					within = false
+				}
+
+				if len(c.Crossings(r[i], r[i+1])) > 0 {
+					within, touches = false, true
+				}
+			}
+		}
+	}
+
+	switch {
+	case within && touches:
+		return RelationWithin, Area(query)
+	case inside && !outside && c.clearOf(query):
+		return RelationContains, length * c.Width
+	case inside || touches:
+		return RelationIntersects, length * c.Width
+	default:
+		return RelationDisjoint, 0
+	}
+}
+
+// routeInside - длина участков маршрута внутри полигонов в метрах и признаки наличия участков внутри и вне них.
+func (c *Corridor) routeInside(query orb.MultiPolygon) (length float64, inside, outside bool) {
+	for i := 0; i < len(c.Route)-1; i++ {
+		a, b := c.Route[i], c.Route[i+1]
+
+		crossings, _ := edgeCrossings(a, b, query)
+		ts := append([]float64{0, 1}, crossings...)
+		sort.Float64s(ts)
+
+		for k := 0; k < len(ts)-1; k++ {
+			if ts[k+1]-ts[k] < minPieceLength {
+				continue
+			}
+
+			from, to := interpolate(a, b, ts[k]), interpolate(a, b, ts[k+1])
+			if !planar.MultiPolygonContains(query, interpolate(from, to, 0.5)) { // nolint:gomnd // середина
+				outside = true
+
+				continue
+			}
+
+			inside = true
+			length += orbgeo.DistanceHaversine(from, to)
+		}
+	}
+
+	return length, inside, outside
+}
+
+// clearOf - граница полигонов отстоит от маршрута не меньше чем на половину ширины коридора.
+func (c *Corridor) clearOf(query orb.MultiPolygon) bool {
+	for _, polygon := range query {
+		for _, r := range polygon {
+			for _, p := range r {
+				if NearestToLine(c.Route, p).Distance < c.halfWidth() {
+					return false
+				}
+			}
+		}
+	}
+
+	for _, p := range c.Route {
+		if NearestToPolygons(query, p).Distance < c.halfWidth() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// align - сдвигает полигоны на 360° по долготе, если в исходной полосе долгот
+// их прямоугольник не пересекает bound, а после сдвига пересекает.
+func align(members orb.MultiPolygon, bound orb.Bound) orb.MultiPolygon {
+	b := members.Bound()
+
+	shifts := segmentShifts(bound, b.Min, b.Max)
+	if len(shifts) == 0 || shifts[0] == 0 {
+		return members
+	}
+
+	res := make(orb.MultiPolygon, len(members))
+
+	for i, polygon := range members {
+		res[i] = make(orb.Polygon, len(polygon))
+
+		for j, r := range polygon {
+			res[i][j] = make(orb.Ring, len(r))
+
+			for k, p := range r {
+				res[i][j][k] = orb.Point{p[0] + shifts[0], p[1]}
+			}
+		}
+	}
+
+	return res
+}
+
+// union - прямоугольник, описывающий все прямоугольники.
+func union(bounds []orb.Bound) orb.Bound {
+	if len(bounds) == 0 {
+		return orb.Bound{Min: orb.Point{math.Inf(1), math.Inf(1)}, Max: orb.Point{math.Inf(-1), math.Inf(-1)}}
+	}
+
+	res := bounds[0]
+	for i := 1; i < len(bounds); i++ {
+		res = res.Union(bounds[i])
+	}
+
+	return res
+}
@@ -0,0 +1,114 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestRelate(t *testing.T) {
+	square := func(minLon, minLat, maxLon, maxLat float64) orb.Polygon {
+		return orb.Polygon{{{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat}, {minLon, maxLat}, {minLon, minLat}}}
+	}
+
+	// площадь квадрата 1°x1° у экватора
+	degree := orb.EarthRadius * orb.EarthRadius * math.Sin(deg2rad(1)) * deg2rad(1)
+
+	geofence, _ := NewPolygons(square(0, 0, 1, 1))
+	withHole, _ := NewPolygons(orb.Polygon{square(0, 0, 1, 1)[0], square(0.4, 0.4, 0.6, 0.6)[0]})
+
+	tests := []struct {
+		name     string
+		query    orb.Geometry
+		shape    Shape
+		want     Relation
+		wantArea float64
+	}{
+		{
+			name:     "intersects",
+			query:    square(0.5, 0, 1.5, 1),
+			shape:    geofence,
+			want:     RelationIntersects,
+			wantArea: degree / 2,
+		},
+		{
+			name:     "within",
+			query:    square(0.25, 0, 0.75, 1),
+			shape:    geofence,
+			want:     RelationWithin,
+			wantArea: degree / 2,
+		},
+		{
+			name:     "contains",
+			query:    square(-1, -1, 2, 2),
+			shape:    geofence,
+			want:     RelationContains,
+			wantArea: degree,
+		},
+		{
+			name:  "disjoint",
+			query: square(2, 0, 3, 1),
+			shape: geofence,
+			want:  RelationDisjoint,
+		},
+		{
+			name:  "geofence in hole",
+			query: orb.Polygon{square(-1, -1, 2, 2)[0], square(-0.5, -0.5, 1.5, 1.5)[0]},
+			shape: geofence,
+			want:  RelationDisjoint,
+		},
+		{
+			name:     "query encloses geofence hole",
+			query:    square(0.2, 0.2, 0.8, 0.8),
+			shape:    withHole,
+			want:     RelationIntersects,
+			wantArea: degree * (0.36 - 0.04),
+		},
+		{
+			name:     "query beside geofence hole",
+			query:    square(0.1, 0.1, 0.3, 0.9),
+			shape:    withHole,
+			want:     RelationWithin,
+			wantArea: degree * 0.16,
+		},
+		{
+			name:     "geofence encloses query hole",
+			query:    orb.Polygon{square(-1, -1, 2, 2)[0], square(0.4, 0.4, 0.6, 0.6)[0]},
+			shape:    geofence,
+			want:     RelationIntersects,
+			wantArea: degree * (1 - 0.04),
+		},
+		{
+			name:     "circle contained",
+			query:    square(-1, -1, 1, 1),
+			shape:    Circle{Center: orb.Point{0, 0}, Radius: 1000},
+			want:     RelationContains,
+			wantArea: math.Pi * 1000 * 1000,
+		},
+		{
+			name:     "corridor intersects",
+			query:    square(-0.5, 0, 0.5, 0.5),
+			shape:    NewCorridor(orb.LineString{{0, -1}, {0, 1}}, 1000),
+			want:     RelationIntersects,
+			wantArea: 0.5 * deg2rad(1) * orb.EarthRadius * 1000,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			query, _ := NewPolygons(tt.query)
+			got, area := Relate(query, tt.shape)
+			if got != tt.want {
+				t.Errorf("Relate() got = %v, want %v", got, tt.want)
+			}
+			if math.Abs(area-tt.wantArea) > tt.wantArea*0.005 {
+				t.Errorf("Relate() area = %v, want %v", area, tt.wantArea)
+			}
+		})
+	}
+}
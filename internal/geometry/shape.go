@@ -0,0 +1,308 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// Shape - геометрия геозоны, для которой определены вхождение точки и расстояние до границы.
+type Shape interface {
+	// Bounds - прямоугольники, описывающие части геометрии. Каждая часть индексируется в rtree отдельно.
+	// Долгота прямоугольника может выходить за пределы [-180, 180], если часть пересекает антимеридиан
+	Bounds() []orb.Bound
+	// Contains - вхождение точки в геометрию
+	Contains(p orb.Point) bool
+	// MayContain - быстрая проверка возможного вхождения точки в часть геометрии с индексом part.
+	// Если возвращает false, то точка в эту часть геометрии не входит
+	MayContain(part int, p orb.Point) bool
+	// Nearest - ближайшая к точке точка границы геометрии
+	Nearest(p orb.Point) Nearest
+	// Crossings - параметры t из [0, 1] точек a + t(b - a), в которых отрезок ab пересекает границу геометрии,
+	// по возрастанию.
+	// Долгота b может выходить за пределы [-180, 180], если отрезок пересекает антимеридиан
+	Crossings(a, b orb.Point) []float64
+	// Measure - площадь, периметр, центр масс и описывающий прямоугольник геометрии
+	Measure() Measures
+}
+
+// Polygons - геометрия из одного полигона или полигонов мультиполигона.
+// Полигоны, пересекающие антимеридиан, хранятся с непрерывной долготой, выходящей за пределы [-180, 180].
+// Поиск выполняется по упрощенной геометрии. Если сохранена геометрия полного разрешения, то для точек
+// в полосе погрешности упрощения вокруг границы вхождение и расстояние проверяются по ней.
+type Polygons struct {
+	Members orb.MultiPolygon
+	bounds  []orb.Bound
+
+	// геометрия полного разрешения в компактном виде
+	full       []compactPolygon
+	fullBounds []orb.Bound
+	// ширина полосы погрешности упрощения в градусах: граница упрощенной геометрии
+	// отстоит от границы полной не дальше чем на band
+	band float64
+}
+
+// NewPolygons - возвращает список полигонов, из которых состоит геометрия.
+// Для полигона это список из одного элемента, для мультиполигона - все его полигоны.
+func NewPolygons(g orb.Geometry) (*Polygons, bool) {
+	members, ok := unwrapPolygons(g)
+	if !ok {
+		return nil, false
+	}
+
+	p := &Polygons{
+		Members: members,
+		bounds:  make([]orb.Bound, 0, len(members)),
+	}
+
+	for i := 0; i < len(members); i++ {
+		p.bounds = append(p.bounds, members[i].Bound())
+	}
+
+	return p, true
+}
+
+// SetFull - сохраняет геометрию полного разрешения, граница которой отстоит от границы упрощенной геометрии
+// не дальше чем на band градусов.
+func (p *Polygons) SetFull(g orb.Geometry, band float64) bool {
+	members, ok := unwrapPolygons(g)
+	if !ok {
+		return false
+	}
+
+	p.full = make([]compactPolygon, 0, len(members))
+	p.fullBounds = make([]orb.Bound, 0, len(members))
+	p.band = band
+
+	for i := 0; i < len(members); i++ {
+		p.full = append(p.full, newCompactPolygon(members[i]))
+		p.fullBounds = append(p.fullBounds, members[i].Bound())
+	}
+
+	return true
+}
+
+// Bounds - прямоугольники полигонов. Если сохранена геометрия полного разрешения,
+// то прямоугольники расширяются на полосу погрешности упрощения.
+func (p *Polygons) Bounds() []orb.Bound {
+	if p.full == nil {
+		return p.bounds
+	}
+
+	bounds := make([]orb.Bound, 0, len(p.bounds))
+	for i := 0; i < len(p.bounds); i++ {
+		bounds = append(bounds, p.bounds[i].Pad(p.band))
+	}
+
+	return bounds
+}
+
+func (p *Polygons) Contains(point orb.Point) bool {
+	if p.nearBorder(point) {
+		return p.fullContains(point)
+	}
+
+	for i := 0; i < len(p.Members); i++ {
+		if p.partContains(i, point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Polygons) MayContain(part int, point orb.Point) bool {
+	return p.partContains(part, point) || p.nearPartBorder(part, point)
+}
+
+func (p *Polygons) Nearest(point orb.Point) Nearest {
+	return p.nearest(point, p.nearBorder(point))
+}
+
+// NearestExact - ближайшая точка границы геометрии полного разрешения, если она сохранена, независимо
+// от расстояния до границы. Индексы полигона, кольца и ребра соответствуют геометрии, которую возвращает Geometry.
+func (p *Polygons) NearestExact(point orb.Point) Nearest {
+	return p.nearest(point, true)
+}
+
+// Crossings - пересечения отрезка с рёбрами полигонов. Если сохранена геометрия полного разрешения,
+// то пересечения ищутся по ней.
+func (p *Polygons) Crossings(a, b orb.Point) []float64 {
+	full := p.full != nil
+
+	bounds := p.bounds
+	if full {
+		bounds = p.fullBounds
+	}
+
+	var res []float64
+
+	for i := 0; i < len(bounds); i++ {
+		for _, shift := range segmentShifts(bounds[i], a, b) {
+			sa, sb := orb.Point{a[0] + shift, a[1]}, orb.Point{b[0] + shift, b[1]}
+
+			p.memberEdges(i, full, func(_, _ int, c, d orb.Point) bool {
+				if t, _, ok := SegmentIntersection(sa, sb, c, d); ok {
+					res = append(res, t)
+				}
+
+				return true
+			})
+		}
+	}
+
+	sort.Float64s(res)
+
+	return res
+}
+
+// nearest - ближайшая к точке точка границы геометрии полного разрешения, если она сохранена и full = true,
+// иначе упрощенной геометрии.
+func (p *Polygons) nearest(point orb.Point, full bool) Nearest {
+	nearest := Nearest{Distance: math.Inf(1), Member: -1, Ring: -1, Edge: -1}
+
+	count := len(p.Members)
+	if full && p.full != nil {
+		count = len(p.full)
+	}
+
+	for i := 0; i < count; i++ {
+		p.memberEdges(i, full, func(r, e int, a, b orb.Point) bool {
+			if d, closest := DistanceToSegment(point, a, b); d < nearest.Distance {
+				nearest = Nearest{Distance: d, Point: closest, Member: i, Ring: r, Edge: e}
+			}
+
+			return true
+		})
+	}
+
+	nearest.Point = NormalizePoint(nearest.Point)
+
+	return nearest
+}
+
+// memberEdges - обход рёбер полигона member геометрии полного разрешения, если она сохранена и full = true,
+// иначе упрощенной геометрии. Геометрия полного разрешения не восстанавливается целиком.
+// Обход прекращается, если f возвращает false.
+func (p *Polygons) memberEdges(member int, full bool, f func(ring, edge int, a, b orb.Point) bool) {
+	if full && p.full != nil {
+		for r, ring := range p.full[member] {
+			stopped := false
+
+			ring.edges(func(e int, a, b orb.Point) bool {
+				stopped = !f(r, e, a, b)
+
+				return !stopped
+			})
+
+			if stopped {
+				return
+			}
+		}
+
+		return
+	}
+
+	for r, ring := range p.Members[member] {
+		for e := 0; e < len(ring)-1; e++ {
+			if !f(r, e, ring[e], ring[e+1]) {
+				return
+			}
+		}
+	}
+}
+
+// partContains - вхождение точки в упрощенный полигон с индексом part.
+func (p *Polygons) partContains(part int, point orb.Point) bool {
+	if part >= len(p.Members) {
+		return false
+	}
+
+	for _, wrapped := range wrapPoint(p.bounds[part], point) {
+		if planar.PolygonContains(p.Members[part], wrapped) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nearBorder - точка находится в полосе погрешности упрощения вокруг границы.
+func (p *Polygons) nearBorder(point orb.Point) bool {
+	for i := 0; i < len(p.Members); i++ {
+		if p.nearPartBorder(i, point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nearPartBorder - точка находится в полосе погрешности упрощения вокруг границы полигона с индексом part.
+// Расстояние считается на плоскости в градусах, так же как выполняется проверка вхождения.
+func (p *Polygons) nearPartBorder(part int, point orb.Point) bool {
+	if p.full == nil || part >= len(p.Members) {
+		return false
+	}
+
+	bound := p.bounds[part].Pad(p.band)
+
+	for _, wrapped := range wrapPoint(bound, point) {
+		if bound.Contains(wrapped) && planar.DistanceFrom(p.Members[part], wrapped) <= p.band {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fullContains - вхождение точки в геометрию полного разрешения.
+func (p *Polygons) fullContains(point orb.Point) bool {
+	for i := 0; i < len(p.full); i++ {
+		for _, wrapped := range wrapPoint(p.fullBounds[i], point) {
+			if p.fullBounds[i].Contains(wrapped) && p.full[i].contains(wrapped) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *Polygons) fullMembers() orb.MultiPolygon {
+	members := make(orb.MultiPolygon, 0, len(p.full))
+	for i := 0; i < len(p.full); i++ {
+		members = append(members, p.full[i].polygon())
+	}
+
+	return members
+}
+
+// unwrapPolygons - полигоны геометрии с непрерывной долготой.
+func unwrapPolygons(g orb.Geometry) (orb.MultiPolygon, bool) {
+	var members orb.MultiPolygon
+
+	switch geometry := g.(type) {
+	case orb.Polygon:
+		members = orb.MultiPolygon{geometry}
+	case orb.MultiPolygon:
+		members = geometry
+	default:
+		return nil, false
+	}
+
+	res := make(orb.MultiPolygon, 0, len(members))
+
+	for i := 0; i < len(members); i++ {
+		if len(members[i]) == 0 {
+			continue
+		}
+
+		polygon, _ := unwrapPolygon(members[i])
+		res = append(res, polygon)
+	}
+
+	return res, true
+}
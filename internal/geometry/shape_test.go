@@ -0,0 +1,74 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+func TestPolygons_ContainsNearSimplifiedBorder(t *testing.T) {
+	// у полного полигона на южной границе есть выступ, который упрощение отбросило
+	full := orb.Polygon{{{0, 0}, {0.5, 0}, {0.5, -0.05}, {0.6, -0.05}, {0.6, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+	simplified := orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+	shape, ok := NewPolygons(simplified)
+	if !ok || !shape.SetFull(full, 0.1) {
+		t.Fatal("NewPolygons() failed")
+	}
+
+	tests := []struct {
+		name  string
+		point orb.Point
+		want  bool
+	}{
+		{name: "inside protrusion", point: orb.Point{0.55, -0.02}, want: true},
+		{name: "near border outside", point: orb.Point{0.3, -0.02}, want: false},
+		{name: "far inside", point: orb.Point{0.5, 0.5}, want: true},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := shape.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains() got = %v, want %v", got, tt.want)
+			}
+			if tt.want && !shape.MayContain(0, tt.point) {
+				t.Errorf("MayContain() got = false, want true")
+			}
+		})
+	}
+}
+
+func TestPolygons_CompactMatchesDecoded(t *testing.T) {
+	// полигон с дырой: результаты по компактным кольцам должны совпадать с результатами по восстановленной геометрии
+	full := orb.Polygon{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		{{0.4, 0.4}, {0.6, 0.4}, {0.6, 0.6}, {0.4, 0.6}, {0.4, 0.4}},
+	}
+
+	shape, ok := NewPolygons(orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+	if !ok || !shape.SetFull(full, 0.5) {
+		t.Fatal("NewPolygons() failed")
+	}
+
+	decoded := shape.fullMembers()
+
+	points := []orb.Point{{0.5, 0.5}, {0.2, 0.2}, {0.4, 0.5}, {1, 0.5}, {1.2, 0.5}, {0.45, 0.7}, {0, 0}}
+
+	t.Parallel()
+
+	for _, point := range points {
+		if got, want := shape.fullContains(point), planar.MultiPolygonContains(decoded, point); got != want {
+			t.Errorf("fullContains(%v) got = %v, want %v", point, got, want)
+		}
+
+		got, want := shape.NearestExact(point), NearestToPolygons(decoded, point)
+		if got != want {
+			t.Errorf("NearestExact(%v) got = %+v, want %+v", point, got, want)
+		}
+	}
+}
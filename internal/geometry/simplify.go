@@ -0,0 +1,111 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+	"github.com/paulmach/orb/simplify"
+)
+
+const (
+	// minRingSize - минимальное количество точек замкнутого кольца (треугольник).
+	minRingSize = 4
+	// minLonScale - нижняя граница масштаба долготы, чтобы проекция не вырождалась у полюсов.
+	minLonScale = 0.01
+)
+
+// Simplify - упрощение полигона или мультиполигона алгоритмом Дугласа-Пекера с допуском tolerance метров.
+// Допуск пересчитывается в градусы с учетом широты каждого кольца. Кольца, выродившиеся при упрощении,
+// остаются без изменений, а полигон, кольца которого после упрощения пересекаются, не упрощается.
+// Возвращает упрощенную геометрию, ширину полосы погрешности упрощения в градусах и признак того,
+// что упрощение отбросило часть вершин.
+func Simplify(g orb.Geometry, tolerance float64) (res orb.Geometry, band float64, changed bool) {
+	if tolerance <= 0 {
+		return g, 0, false
+	}
+
+	switch geometry := g.(type) {
+	case orb.Polygon:
+		polygon, b, ch := simplifyPolygon(geometry, tolerance)
+
+		return polygon, b, ch
+	case orb.MultiPolygon:
+		members := make(orb.MultiPolygon, len(geometry))
+
+		for i := range geometry {
+			member, b, ch := simplifyPolygon(geometry[i], tolerance)
+			members[i] = member
+			band = math.Max(band, b)
+			changed = changed || ch
+		}
+
+		return members, band, changed
+	default:
+		return g, 0, false
+	}
+}
+
+// simplifyPolygon - упрощение колец полигона с проверкой отсутствия пересечений между ними.
+func simplifyPolygon(p orb.Polygon, tolerance float64) (orb.Polygon, float64, bool) {
+	unwrapped, _ := unwrapPolygon(p)
+
+	res := make(orb.Polygon, len(unwrapped))
+	band := 0.0
+	changed := false
+
+	for i := range unwrapped {
+		r, b := simplifyRing(unwrapped[i], tolerance)
+		if len(r) < minRingSize {
+			r, b = unwrapped[i], 0
+		}
+
+		res[i] = r
+		band = math.Max(band, b)
+		changed = changed || len(r) < len(unwrapped[i])
+	}
+
+	if !changed || !validRings(res) {
+		return p, 0, false
+	}
+
+	return res, band, true
+}
+
+// simplifyRing - упрощение кольца в локальной проекции, где градус долготы приведен к градусу широты
+// на средней широте кольца. Возвращает новое кольцо и ширину полосы погрешности в градусах.
+func simplifyRing(r orb.Ring, tolerance float64) (orb.Ring, float64) {
+	if len(r) <= minRingSize {
+		return r, 0
+	}
+
+	scale := math.Max(math.Cos(deg2rad(r.Bound().Center().Lat())), minLonScale)
+	threshold := rad2deg(tolerance / orb.EarthRadius)
+
+	projected := make(orb.Ring, len(r))
+	for i := range r {
+		projected[i] = orb.Point{r[i][0] * scale, r[i][1]}
+	}
+
+	projected = simplify.DouglasPeucker(threshold).Ring(projected)
+	for i := range projected {
+		projected[i][0] /= scale
+	}
+
+	return projected, threshold / scale
+}
+
+// validRings - кольца полигона не пересекаются, а дыры лежат внутри внешнего кольца.
+func validRings(p orb.Polygon) bool {
+	if len(PolygonIntersections(p, 1)) > 0 {
+		return false
+	}
+
+	for i := 1; i < len(p); i++ {
+		if len(p[i]) > 0 && !planar.RingContains(p[0], p[i][0]) {
+			return false
+		}
+	}
+
+	return true
+}
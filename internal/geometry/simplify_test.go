@@ -0,0 +1,92 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestSimplify(t *testing.T) {
+	// выступ на восточной границе квадрата на широте 60°, 1° долготы здесь ~ 55.7 км
+	bump := func(width float64) orb.Polygon {
+		return orb.Polygon{{{0, 60}, {1, 60}, {1, 60.5}, {1 + width, 60.505}, {1, 60.51}, {1, 61}, {0, 61}, {0, 60}}}
+	}
+
+	tests := []struct {
+		name        string
+		polygon     orb.Polygon
+		tolerance   float64
+		wantChanged bool
+	}{
+		{
+			name:        "bump 40 m dropped",
+			polygon:     bump(40 / 55660.0),
+			tolerance:   50,
+			wantChanged: true,
+		},
+		{
+			name:        "bump 60 m kept",
+			polygon:     bump(60 / 55660.0),
+			tolerance:   50,
+			wantChanged: false,
+		},
+		{
+			name:        "zero tolerance",
+			polygon:     bump(40 / 55660.0),
+			tolerance:   0,
+			wantChanged: false,
+		},
+		{
+			name: "triangle not collapsed",
+			polygon: orb.Polygon{
+				{{0, 0}, {0.0001, 0}, {0.0002, 0.00001}, {0.0001, 0.0001}, {0, 0}},
+			},
+			tolerance:   50,
+			wantChanged: false,
+		},
+		{
+			name: "hole crosses simplified border",
+			polygon: orb.Polygon{
+				{{0, 0}, {0.5, 0}, {0.5005, -0.0003}, {0.501, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+				{{0.5004, 0.0001}, {0.5005, -0.0002}, {0.5006, 0.0001}, {0.5004, 0.0001}},
+			},
+			tolerance:   50,
+			wantChanged: false,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, band, changed := Simplify(tt.polygon, tt.tolerance)
+			if changed != tt.wantChanged {
+				t.Fatalf("Simplify() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			polygon := res.(orb.Polygon)
+			for i := range polygon {
+				if len(polygon[i]) < minRingSize {
+					t.Errorf("Simplify() ring %d collapsed: %v", i, polygon[i])
+				}
+			}
+			if changed && band <= 0 {
+				t.Errorf("Simplify() band = %v, want > 0", band)
+			}
+		})
+	}
+}
+
+func TestPolygonIntersections(t *testing.T) {
+	bowtie := orb.Polygon{{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}}
+	square := orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+	if got := PolygonIntersections(bowtie, 0); len(got) != 1 || got[0].Point != (orb.Point{0.5, 0.5}) {
+		t.Errorf("PolygonIntersections() bowtie = %v, want one at (0.5, 0.5)", got)
+	}
+
+	if got := PolygonIntersections(square, 0); len(got) != 0 {
+		t.Errorf("PolygonIntersections() square = %v, want none", got)
+	}
+}
@@ -0,0 +1,232 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// Defect - вид дефекта геометрии.
+type Defect string
+
+const (
+	// DefectCoordinateRange - координата вне допустимого диапазона. Долгота приводится к [-180, 180],
+	// широта вне [-90, 90] и нечисловые координаты не исправляются.
+	DefectCoordinateRange Defect = "coordinate_out_of_range"
+	// DefectUnclosedRing - первая и последняя точки кольца не совпадают. Кольцо замыкается.
+	DefectUnclosedRing Defect = "unclosed_ring"
+	// DefectDuplicateVertex - повторяющиеся соседние вершины. Повторы удаляются.
+	DefectDuplicateVertex Defect = "duplicate_vertex"
+	// DefectOrientation - внешнее кольцо ориентировано по часовой стрелке или дыра против. Кольцо разворачивается.
+	DefectOrientation Defect = "ring_orientation"
+	// DefectDegenerateHole - дыра меньше чем из трех вершин или нулевой площади. Дыра удаляется.
+	DefectDegenerateHole Defect = "degenerate_hole"
+	// DefectDegenerateRing - внешнее кольцо меньше чем из трех вершин или нулевой площади.
+	// Полигон удаляется из мультиполигона, если в нём остаются другие полигоны.
+	DefectDegenerateRing Defect = "degenerate_ring"
+	// DefectSelfIntersection - рёбра колец полигона пересекаются. Не исправляется.
+	DefectSelfIntersection Defect = "self_intersection"
+	// DefectHoleOutside - дыра лежит вне внешнего кольца. Не исправляется.
+	DefectHoleOutside Defect = "hole_outside_shell"
+	// DefectShortRoute - маршрут коридора меньше чем из двух различных точек. Не исправляется.
+	DefectShortRoute Defect = "short_route"
+)
+
+// maxIntersectionIssues - максимальное количество пересечений рёбер, сообщаемых для одного полигона.
+const maxIntersectionIssues = 10
+
+// Issue - дефект геометрии: полигон мультиполигона, кольцо и точка, в которой он найден.
+type Issue struct {
+	Defect   Defect
+	Member   int
+	Ring     int
+	Point    orb.Point
+	Repaired bool
+}
+
+func (i Issue) String() string {
+	state := "not repaired"
+	if i.Repaired {
+		state = "repaired"
+	}
+
+	return fmt.Sprintf("%s at polygon %d ring %d (%f, %f), %s", i.Defect, i.Member, i.Ring, i.Point.Lon(), i.Point.Lat(), state)
+}
+
+// ValidCoordinates - координаты точки числовые и находятся в допустимом диапазоне.
+func ValidCoordinates(p orb.Point) bool {
+	return !math.IsNaN(p[0]) && !math.IsNaN(p[1]) && math.Abs(p[0]) <= maxLon && math.Abs(p[1]) <= maxLatitude
+}
+
+// Repair - проверка полигона или мультиполигона и исправление дефектов, которые исправляются автоматически.
+// Возвращает исправленную геометрию, список найденных дефектов и признак того, что все дефекты исправлены.
+func Repair(g orb.Geometry) (orb.Geometry, []Issue, bool) {
+	switch geometry := g.(type) {
+	case orb.Polygon:
+		polygon, issues := repairPolygon(0, geometry)
+		if polygon == nil {
+			return nil, keepDegenerate(issues), false
+		}
+
+		return polygon, issues, repaired(issues)
+	case orb.MultiPolygon:
+		var issues []Issue
+
+		members := make(orb.MultiPolygon, 0, len(geometry))
+
+		for i := range geometry {
+			polygon, polygonIssues := repairPolygon(i, geometry[i])
+			issues = append(issues, polygonIssues...)
+
+			if polygon != nil {
+				members = append(members, polygon)
+			}
+		}
+
+		if len(members) == 0 {
+			return nil, keepDegenerate(issues), false
+		}
+
+		return members, issues, repaired(issues)
+	default:
+		return g, nil, false
+	}
+}
+
+// RepairRoute - проверка маршрута коридора: координаты, повторяющиеся точки и количество точек.
+func RepairRoute(route orb.LineString) (orb.LineString, []Issue, bool) {
+	points, issues := repairPoints(0, 0, route)
+	if len(points) < 2 { // nolint:gomnd // отрезок из двух точек
+		issues = append(issues, Issue{Defect: DefectShortRoute})
+
+		return points, issues, false
+	}
+
+	return points, issues, repaired(issues)
+}
+
+// repairPolygon - исправление колец полигона с индексом member. Возвращает nil, если внешнее кольцо выродилось.
+func repairPolygon(member int, p orb.Polygon) (orb.Polygon, []Issue) {
+	var issues []Issue
+
+	res := make(orb.Polygon, 0, len(p))
+
+	for j := range p {
+		points, ringIssues := repairPoints(member, j, orb.LineString(p[j]))
+		issues = append(issues, ringIssues...)
+
+		r := orb.Ring(points)
+
+		if len(r) > 0 && r[0] != r[len(r)-1] {
+			issues = append(issues, Issue{Defect: DefectUnclosedRing, Member: member, Ring: j, Point: r[0], Repaired: true})
+			r = append(r, r[0])
+		}
+
+		unwrapped, _ := unwrapRing(r)
+
+		if len(r) < minRingSize || planar.Area(unwrapped) == 0 {
+			if j == 0 {
+				issues = append(issues, Issue{Defect: DefectDegenerateRing, Member: member, Ring: j, Repaired: true})
+
+				return nil, issues
+			}
+
+			issues = append(issues, Issue{Defect: DefectDegenerateHole, Member: member, Ring: j, Repaired: true})
+
+			continue
+		}
+
+		want := orb.CCW
+		if j > 0 {
+			want = orb.CW
+		}
+
+		if unwrapped.Orientation() != want {
+			issues = append(issues, Issue{Defect: DefectOrientation, Member: member, Ring: j, Point: r[0], Repaired: true})
+			r.Reverse()
+		}
+
+		res = append(res, r)
+	}
+
+	return res, append(issues, topologyIssues(member, res)...)
+}
+
+// repairPoints - проверка координат и удаление повторяющихся соседних точек.
+// Точки с недопустимой широтой или нечисловыми координатами остаются без изменений.
+func repairPoints(member, ring int, points orb.LineString) (orb.LineString, []Issue) {
+	var issues []Issue
+
+	res := make(orb.LineString, 0, len(points))
+
+	for _, p := range points {
+		if !ValidCoordinates(p) {
+			normalized := p
+			if !math.IsNaN(p[0]) && !math.IsInf(p[0], 0) {
+				normalized = NormalizePoint(p)
+			}
+
+			issues = append(issues, Issue{
+				Defect: DefectCoordinateRange, Member: member, Ring: ring, Point: p,
+				Repaired: ValidCoordinates(normalized),
+			})
+			p = normalized
+		}
+
+		if len(res) > 0 && res[len(res)-1] == p {
+			issues = append(issues, Issue{Defect: DefectDuplicateVertex, Member: member, Ring: ring, Point: p, Repaired: true})
+
+			continue
+		}
+
+		res = append(res, p)
+	}
+
+	return res, issues
+}
+
+// topologyIssues - пересечения рёбер колец и дыры вне внешнего кольца.
+func topologyIssues(member int, p orb.Polygon) []Issue {
+	var issues []Issue
+
+	unwrapped, _ := unwrapPolygon(p)
+
+	for _, intersection := range PolygonIntersections(unwrapped, maxIntersectionIssues) {
+		issues = append(issues, Issue{
+			Defect: DefectSelfIntersection, Member: member, Ring: intersection.RingA,
+			Point: NormalizePoint(intersection.Point),
+		})
+	}
+
+	for j := 1; j < len(unwrapped); j++ {
+		if !planar.RingContains(unwrapped[0], unwrapped[j][0]) {
+			issues = append(issues, Issue{Defect: DefectHoleOutside, Member: member, Ring: j, Point: p[j][0]})
+		}
+	}
+
+	return issues
+}
+
+// keepDegenerate - если выродились все полигоны геометрии, то удаление полигонов не исправляет дефект.
+func keepDegenerate(issues []Issue) []Issue {
+	for i := range issues {
+		if issues[i].Defect == DefectDegenerateRing {
+			issues[i].Repaired = false
+		}
+	}
+
+	return issues
+}
+
+// repaired - все дефекты исправлены.
+func repaired(issues []Issue) bool {
+	for _, issue := range issues {
+		if !issue.Repaired {
+			return false
+		}
+	}
+
+	return true
+}
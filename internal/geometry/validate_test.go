@@ -0,0 +1,88 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		name      string
+		geometry  orb.Geometry
+		wantValid bool
+		want      []Defect
+	}{
+		{
+			name:      "valid",
+			geometry:  orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+			wantValid: true,
+		},
+		{
+			name:      "unclosed clockwise with duplicate",
+			geometry:  orb.Polygon{{{0, 0}, {0, 1}, {0, 1}, {1, 1}, {1, 0}}},
+			wantValid: true,
+			want:      []Defect{DefectDuplicateVertex, DefectUnclosedRing, DefectOrientation},
+		},
+		{
+			name: "degenerate hole",
+			geometry: orb.Polygon{
+				{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+				{{0.2, 0.2}, {0.4, 0.4}, {0.6, 0.6}, {0.2, 0.2}},
+			},
+			wantValid: true,
+			want:      []Defect{DefectDegenerateHole},
+		},
+		{
+			name:      "self intersection",
+			geometry:  orb.Polygon{{{0, 0}, {2, 2}, {2, 0}, {0, 1}, {0, 0}}},
+			wantValid: false,
+			want:      []Defect{DefectOrientation, DefectSelfIntersection},
+		},
+		{
+			name: "hole outside",
+			geometry: orb.Polygon{
+				{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+				{{2, 2}, {2, 3}, {3, 3}, {2, 2}},
+			},
+			wantValid: false,
+			want:      []Defect{DefectHoleOutside},
+		},
+		{
+			name:      "latitude out of range",
+			geometry:  orb.Polygon{{{0, 0}, {1, 0}, {1, 95}, {0, 0}}},
+			wantValid: false,
+			want:      []Defect{DefectCoordinateRange},
+		},
+		{
+			name: "degenerate member dropped",
+			geometry: orb.MultiPolygon{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+				{{{2, 2}, {3, 3}, {2, 2}}},
+			},
+			wantValid: true,
+			want:      []Defect{DefectDegenerateRing},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, issues, valid := Repair(tt.geometry)
+			if valid != tt.wantValid {
+				t.Errorf("Repair() valid = %v, want %v, issues %v", valid, tt.wantValid, issues)
+			}
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Repair() issues = %v, want %v", issues, tt.want)
+			}
+			for i := range issues {
+				if issues[i].Defect != tt.want[i] {
+					t.Errorf("Repair() issues = %v, want %v", issues, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package geocache
+
+import (
+	"sort"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// FindGeofencesInBounds - поиск геозон, описывающий прямоугольник которых пересекает прямоугольник bound.
+// Долгота bound может выходить за пределы [-180, 180], если он пересекает антимеридиан.
+// Полигоны дополнительно упрощаются с допуском tolerance метров, 0 - без дополнительного упрощения.
+// Результат упорядочен по id полигона.
+func (m *MemoryGeoCache) FindGeofencesInBounds(bound orb.Bound, userID *uint64,
+	tolerance float64, filter *models.Filter) ([]models.GeofenceGeometry, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	intersects := m.search(bound, filter)
+
+	geofences := make([]models.GeofenceGeometry, 0, len(intersects))
+	found := make(map[uint64]struct{}, len(intersects))
+
+	for i := 0; i < len(intersects); i++ {
+		gz, isGeozone := intersects[i].(*models.Geofence)
+		if !isGeozone {
+			continue
+		}
+
+		if _, ok := found[gz.PolygonID]; ok {
+			continue
+		}
+
+		found[gz.PolygonID] = struct{}{}
+
+		gzExt, ok := m.geofenceExtCache[gz.PolygonID]
+		if !ok {
+			continue
+		}
+
+		if userID != nil && *userID != gzExt.UserID {
+			continue
+		}
+
+		geofences = append(geofences, toGeometry(gzExt, tolerance))
+	}
+
+	sort.Slice(geofences, func(i, j int) bool {
+		return geofences[i].PolygonID < geofences[j].PolygonID
+	})
+
+	return geofences, nil
+}
+
+// toGeometry - геометрия геозоны для отображения: исправленный маршрут коридора и упрощенные полигоны,
+// по которым выполняется поиск. Долгота вершин приводится к диапазону [-180, 180].
+func toGeometry(ext *models.GeofenceExt, tolerance float64) models.GeofenceGeometry {
+	res := models.GeofenceGeometry{
+		PolygonID:  ext.PolygonID,
+		GeofenceID: ext.GeofenceID,
+		UserID:     ext.UserID,
+		Title:      ext.Title,
+		Kind:       ext.Kind,
+		Radius:     ext.Radius,
+		Width:      ext.Width,
+	}
+
+	switch ext.Kind {
+	case models.KindCircle:
+		res.Geometry = ext.Center.Geometry()
+	case models.KindCorridor:
+		if corridor, ok := ext.Shape.(*geometry.Corridor); ok {
+			res.Geometry = corridor.Route
+		} else {
+			res.Geometry = ext.Route.Geometry()
+		}
+	default:
+		res.Geometry, _, _ = geometry.Simplify(ext.GeometrySimplify.Geometry(), tolerance)
+	}
+
+	res.Geometry = geometry.NormalizeGeometry(res.Geometry)
+
+	return res
+}
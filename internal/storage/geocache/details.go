@@ -0,0 +1,60 @@
+package geocache
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// GetGeofence - геометрия и характеристики полигонов геозоны. Если задан id полигона, то возвращается
+// только этот полигон, иначе все полигоны геозоны. Если геозона или полигон не найдены
+// или не удовлетворяют фильтру, то возвращает storage.ErrNotFound.
+func (m *MemoryGeoCache) GetGeofence(geofenceID, polygonID uint64,
+	filter *models.Filter) ([]models.GeofenceDetails, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	polygonsID := m.geofenceLinkedToPolygon[geofenceID]
+	if polygonID != 0 {
+		polygonsID = []uint64{polygonID}
+	}
+
+	details := make([]models.GeofenceDetails, 0, len(polygonsID))
+
+	for i := 0; i < len(polygonsID); i++ {
+		gzExt, ok := m.geofenceExtCache[polygonsID[i]]
+		if !ok || (geofenceID != 0 && gzExt.GeofenceID != geofenceID) || !filter.Match(gzExt) {
+			continue
+		}
+
+		res := models.GeofenceDetails{
+			GeofenceGeometry: toGeometry(gzExt, 0),
+			Measures:         gzExt.Shape.Measure(),
+		}
+
+		// для полигона возвращаем геометрию полного разрешения
+		if polygons, ok := gzExt.Shape.(*geometry.Polygons); ok {
+			if members := polygons.Geometry(); len(members) == 1 {
+				res.Geometry = geometry.NormalizeGeometry(members[0])
+			} else {
+				res.Geometry = geometry.NormalizeGeometry(members)
+			}
+		}
+
+		details = append(details, res)
+	}
+
+	if len(details) == 0 {
+		return nil, errors.Wrapf(storage.ErrNotFound, "geofence %d polygon %d", geofenceID, polygonID)
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].PolygonID < details[j].PolygonID
+	})
+
+	return details, nil
+}
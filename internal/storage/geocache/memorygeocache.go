@@ -2,20 +2,24 @@ package geocache
 
 import (
 	"fmt"
+	"math"
 	"sync"
 
 	"github.com/dhconnelly/rtreego"
 	gogeo "github.com/kellydunn/golang-geo"
 	"github.com/paulmach/orb"
 	orbgeo "github.com/paulmach/orb/geo"
-	"github.com/paulmach/orb/planar"
 	"github.com/pkg/errors"
 
+	"github.com/X-Keeper/geoborder/internal/geometry"
 	"github.com/X-Keeper/geoborder/internal/storage"
 	"github.com/X-Keeper/geoborder/internal/storage/models"
 	"github.com/X-Keeper/geoborder/pkg/logger"
 )
 
+// fullCircle - полный угол, в градусах.
+const fullCircle = 360
+
 // MemoryGeoCache - in-memory cache для хранения информации о геозонах.
 type MemoryGeoCache struct {
 	sync.RWMutex
@@ -26,6 +30,8 @@ type MemoryGeoCache struct {
 	geofenceExtCache map[uint64]*models.GeofenceExt
 	// данные о геозонах, key - это id геозоны, значение id - полигона
 	geofenceLinkedToPolygon map[uint64][]uint64
+	// полигоны геозон с неисправимыми дефектами геометрии, key - id полигона
+	quarantine map[uint64]models.QuarantinedGeofence
 	// сбалансированное дерево поиска для хранения и запросов bounding box геозон
 	rtree *rtreego.Rtree
 	// логгирование
@@ -37,41 +43,44 @@ func NewMemoryCache(db storage.GeoStorage, log *logger.Logger) (*MemoryGeoCache,
 		return nil, errors.New("no database connection")
 	}
 
-	dimensions := 2
-	minChildren := 25
-	maxChildren := 4096
-
 	return &MemoryGeoCache{
 		db:                      db,
 		geofenceExtCache:        make(map[uint64]*models.GeofenceExt),
 		geofenceLinkedToPolygon: make(map[uint64][]uint64),
-		rtree:                   rtreego.NewTree(dimensions, minChildren, maxChildren),
+		quarantine:              make(map[uint64]models.QuarantinedGeofence),
+		rtree:                   newTree(),
 		log:                     log,
 	}, nil
 }
 
+func newTree() *rtreego.Rtree {
+	dimensions := 2
+	minChildren := 25
+	maxChildren := 4096
+
+	return rtreego.NewTree(dimensions, minChildren, maxChildren)
+}
+
 func (m *MemoryGeoCache) Load() (count int, err error) {
-	if m.geofenceExtCache, err = m.db.GetFullGeometry(); err != nil {
+	var res map[uint64]*models.GeofenceExt
+
+	if res, err = m.db.GetFullGeometry(); err != nil {
 		logger.LogError(err, m.log)
 
 		return 0, errors.Wrap(err, "error load full geometry")
 	}
 
-	m.RLock()
-	defer m.RUnlock()
+	m.Lock()
+	defer m.Unlock()
 
-	for _, geofences := range m.geofenceExtCache {
-		m.rtree.Insert(&models.Geofence{
-			PolygonID:   geofences.PolygonID,
-			GeofenceID:  geofences.GeofenceID,
-			Title:       geofences.Title,
-			UserID:      geofences.UserID,
-			BoundingBox: geofences.BoundingBox,
-		})
+	// полная загрузка заменяет кэш целиком: индекс и связи геозон с полигонами строятся заново
+	m.geofenceExtCache = make(map[uint64]*models.GeofenceExt, len(res))
+	m.geofenceLinkedToPolygon = make(map[uint64][]uint64, len(res))
+	m.quarantine = make(map[uint64]models.QuarantinedGeofence)
+	m.rtree = newTree()
 
-		polygons := m.geofenceLinkedToPolygon[geofences.GeofenceID]
-		polygons = append(polygons, geofences.PolygonID)
-		m.geofenceLinkedToPolygon[geofences.GeofenceID] = polygons
+	for _, geofences := range res {
+		m.insert(geofences)
 	}
 
 	logger.LogDebug(fmt.Sprintf("[MEMORY_GEO_CAHCE]::Load : loaded %d geofences", m.rtree.Size()), m.log)
@@ -83,10 +92,17 @@ func (m *MemoryGeoCache) Load() (count int, err error) {
 // Переделать на уведомления от postgres - https://habr.com/ru/company/tensor/blog/484978/
 func (m *MemoryGeoCache) Update() (count int, err error) {
 	var res map[uint64]*models.GeofenceExt
-	ids := make([]uint64, 0, len(m.geofenceLinkedToPolygon))
+
+	m.RLock()
+	ids := make([]uint64, 0, len(m.geofenceLinkedToPolygon)+len(m.quarantine))
 	for _, v := range m.geofenceLinkedToPolygon {
 		ids = append(ids, v...)
 	}
+	// геозоны в карантине повторно не загружаем
+	for id := range m.quarantine {
+		ids = append(ids, id)
+	}
+	m.RUnlock()
 
 	if res, err = m.db.GetNewRecords(ids); err != nil {
 		logger.LogError(err, m.log)
@@ -99,29 +115,87 @@ func (m *MemoryGeoCache) Update() (count int, err error) {
 	}
 	m.Lock()
 	defer m.Unlock()
-	for k, ext := range res {
-		m.geofenceExtCache[k] = ext
-
-		polygons := m.geofenceLinkedToPolygon[ext.GeofenceID]
-		polygons = append(polygons, ext.PolygonID)
-		m.geofenceLinkedToPolygon[ext.GeofenceID] = polygons
+	for _, ext := range res {
+		m.insert(ext)
 	}
 	logger.LogDebug(fmt.Sprintf("[MEMORY_GEO_CACHE]::Update : add %d new records", len(res)), m.log)
 
 	return len(res), nil
 }
 
+// insert - добавляет геозону в кэш и rtree. Для мультиполигона в дерево добавляется
+// описывающий прямоугольник каждого полигона из его состава, для части геометрии,
+// пересекающей антимеридиан, - прямоугольники по обе стороны от него.
+// Геозона с неисправимыми дефектами геометрии помещается в карантин.
+// Вызывается под блокировкой на запись.
+func (m *MemoryGeoCache) insert(ext *models.GeofenceExt) {
+	if ext.QuarantineReason != "" || ext.Shape == nil {
+		m.quarantine[ext.PolygonID] = models.QuarantinedGeofence{
+			PolygonID:  ext.PolygonID,
+			GeofenceID: ext.GeofenceID,
+			UserID:     ext.UserID,
+			Title:      ext.Title,
+			Reason:     ext.QuarantineReason,
+			Issues:     ext.Issues,
+		}
+
+		return
+	}
+
+	m.geofenceExtCache[ext.PolygonID] = ext
+
+	bounds := ext.Shape.Bounds()
+
+	for i := 0; i < len(bounds); i++ {
+		for _, bound := range geometry.SplitAntimeridian(bounds[i]) {
+			m.rtree.Insert(&models.Geofence{
+				PolygonID:   ext.PolygonID,
+				GeofenceID:  ext.GeofenceID,
+				Title:       ext.Title,
+				UserID:      ext.UserID,
+				BoundingBox: rect(bound),
+				MemberIndex: i,
+				Category:    ext.Category,
+				Tags:        ext.Tags,
+				Attributes:  ext.Attributes,
+			})
+		}
+	}
+
+	polygonsID := m.geofenceLinkedToPolygon[ext.GeofenceID]
+	polygonsID = append(polygonsID, ext.PolygonID)
+	m.geofenceLinkedToPolygon[ext.GeofenceID] = polygonsID
+}
+
 // FindGeofenceByPoint - поиск вхождения точки в геозону
 // поиск разбит на 2 этапа:
-// 1 этап - ищем в rtree пересечение точки с описывающим геозону прямоугольником
+// 1 этап - ищем в rtree пересечение точки с описывающим геозону (или полигон мультиполигона) прямоугольником
 // 2 этап - проверяем по списку полученных прямоугольников вхождение точки в упрощенный полигон геозоны.
-func (m *MemoryGeoCache) FindGeofenceByPoint(point orb.Point, userID *uint64, withDistance bool) ([]models.Geofence, error) {
-	rpt := rtreego.Point{point.X(), point.Y()}
+// Если задана точность координат, то точка рассматривается как круг и в результат попадают также геозоны,
+// граница которых проходит через этот круг, с классификацией ContainmentBoundaryUncertain.
+// Геозоны, не удовлетворяющие фильтру, отбрасываются при поиске в rtree.
+func (m *MemoryGeoCache) FindGeofenceByPoint(point models.Point, userID *uint64, withDistance bool,
+	filter *models.Filter) ([]models.Geofence, error) {
+	m.RLock()
+	defer m.RUnlock()
+
 	// выполняем поиск пересечения точки с описывающим геозону прямоугольником
 	epsilon := 0.0005
-	intersects := m.rtree.SearchIntersect(rpt.ToRect(epsilon))
+	searchBound := orb.Bound{
+		Min: orb.Point{point.X() - epsilon, point.Y() - epsilon},
+		Max: orb.Point{point.X() + epsilon, point.Y() + epsilon},
+	}
+
+	if point.Accuracy > 0 {
+		searchBound = geometry.BoundAround(point.Point, point.Accuracy)
+	}
+
+	intersects := m.search(searchBound, filter)
+	at := point.At()
 
 	geofences := make([]models.Geofence, 0, len(intersects))
+	// полигон мультиполигона может попасть в выборку несколько раз
+	found := make(map[uint64]struct{}, len(intersects))
 
 	var gz *models.Geofence
 	var gzExt *models.GeofenceExt
@@ -133,6 +207,10 @@ func (m *MemoryGeoCache) FindGeofenceByPoint(point orb.Point, userID *uint64, wi
 			continue
 		}
 
+		if _, ok = found[gz.PolygonID]; ok {
+			continue
+		}
+
 		// делаем поиск расширенного описания геозоны по id полигона, который её описывает
 		if gzExt, ok = m.geofenceExtCache[gz.PolygonID]; !ok {
 			continue
@@ -142,55 +220,181 @@ func (m *MemoryGeoCache) FindGeofenceByPoint(point orb.Point, userID *uint64, wi
 			continue
 		}
 
-		// получаем описание полигона
-		if polygon, isPoly := gzExt.GeometrySimplify.Geometry().(orb.Polygon); isPoly {
-			if planar.PolygonContains(polygon, point) {
-				if withDistance {
-					_, index := planar.DistanceFromWithIndex(polygon, point)
-					gz.Distance = orbgeo.Distance(polygon[0][index], point)
-				}
-				geofences = append(geofences, *gz)
-			}
+		// геозона вне своего расписания не действует
+		if !gzExt.ActiveAt(at) {
+			continue
+		}
+
+		// для точки без погрешности сначала проверяем часть геометрии геозоны,
+		// чей прямоугольник пересекла точка
+		if point.Accuracy == 0 && !gzExt.Shape.MayContain(gz.MemberIndex, point.Point) {
+			continue
+		}
+
+		found[gz.PolygonID] = struct{}{}
+
+		res := *gz
+		res.Distance, res.Containment, res.Boundary = locate(gzExt.Shape, point, withDistance)
+
+		if res.Containment == models.ContainmentOutside {
+			continue
 		}
+
+		res.Bearing = bearing(point, res.Boundary)
+
+		if !locateVertical(&res, gzExt, point, withDistance) {
+			continue
+		}
+
+		locateOnRoute(&res, gzExt.Shape, point)
+		geofences = append(geofences, res)
 	}
 
 	return geofences, nil
 }
-func (m *MemoryGeoCache) CheckGeofenceByPoint(point orb.Point, geofenceID []uint64) ([]models.Geofence, error) {
+
+// CheckGeofenceByPoint - проверка вхождения точки в заданные геозоны, удовлетворяющие фильтру.
+// Если какой-либо из геозон нет в кэше, то возвращает storage.ErrNotFound.
+func (m *MemoryGeoCache) CheckGeofenceByPoint(point models.Point, geofenceID []uint64,
+	filter *models.Filter) ([]models.Geofence, error) {
 	defaultCap := 2
 	geofences := make([]models.Geofence, 0, defaultCap)
 
+	m.RLock()
+	defer m.RUnlock()
+
+	at := point.At()
+
 	for i := 0; i < len(geofenceID); i++ {
-		polygonsID := m.geofenceLinkedToPolygon[geofenceID[i]]
+		polygonsID, ok := m.geofenceLinkedToPolygon[geofenceID[i]]
+		if !ok {
+			return nil, errors.Wrapf(storage.ErrNotFound, "geofence %d", geofenceID[i])
+		}
+
 		var gzExt *models.GeofenceExt
-		var ok bool
 
 		for i := 0; i < len(polygonsID); i++ {
 			// делаем поиск расширенного описания геозоны по id полигона, который её описывает
-			if gzExt, ok = m.geofenceExtCache[polygonsID[i]]; !ok {
+			if gzExt, ok = m.geofenceExtCache[polygonsID[i]]; !ok || !gzExt.ActiveAt(at) || !filter.Match(gzExt) {
+				continue
+			}
+
+			_, containment, _ := locate(gzExt.Shape, point, false)
+			if containment == models.ContainmentOutside {
 				continue
 			}
 
-			// получаем описание полигона
-			if polygon, isPoly := gzExt.GeometrySimplify.Geometry().(orb.Polygon); isPoly {
-				if planar.PolygonContains(polygon, point) {
-					geofences = append(geofences, models.Geofence{
-						PolygonID:  gzExt.PolygonID,
-						GeofenceID: gzExt.GeofenceID,
-						UserID:     gzExt.UserID,
-						Title:      gzExt.Title,
-						Distance:   0,
-					})
-				}
+			res := models.Geofence{
+				PolygonID:   gzExt.PolygonID,
+				GeofenceID:  gzExt.GeofenceID,
+				UserID:      gzExt.UserID,
+				Title:       gzExt.Title,
+				Distance:    0,
+				Containment: containment,
+				Category:    gzExt.Category,
+				Tags:        gzExt.Tags,
+				Attributes:  gzExt.Attributes,
 			}
+
+			if !locateVertical(&res, gzExt, point, false) {
+				continue
+			}
+
+			locateOnRoute(&res, gzExt.Shape, point)
+			geofences = append(geofences, res)
 		}
 	}
 
 	return geofences, nil
 }
 
-func (m *MemoryGeoCache) GetDistanceToGeofence(point orb.Point) ([]models.Geofence, error) {
-	return m.FindGeofenceByPoint(point, nil, true)
+func (m *MemoryGeoCache) GetDistanceToGeofence(point models.Point, userID *uint64,
+	filter *models.Filter) ([]models.Geofence, error) {
+	return m.FindGeofenceByPoint(point, userID, true, filter)
+}
+
+// FindNearbyGeofences - поиск геозон, в которые попадает точка или граница которых находится
+// не дальше radius метров от неё. Дистанция до границы возвращается со знаком:
+// отрицательная - точка внутри геозоны, положительная - снаружи. Точка с высотой вне диапазона высот
+// геозоны находится снаружи, дистанция до такой геозоны учитывает расстояние по вертикали.
+// Кандидаты отбираются в rtree по прямоугольнику, описывающему окружность поиска.
+// userID - геозоны пользователя, nil - геозоны всех пользователей.
+func (m *MemoryGeoCache) FindNearbyGeofences(point models.Point, radius float64, userID *uint64,
+	filter *models.Filter) ([]models.Geofence, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.nearby(point, radius, userID, filter), nil
+}
+
+// nearby - поиск полигонов геозон в радиусе radius метров от точки. Вызывается под блокировкой на чтение.
+func (m *MemoryGeoCache) nearby(point models.Point, radius float64, userID *uint64,
+	filter *models.Filter) []models.Geofence {
+	intersects := m.search(geometry.BoundAround(point.Point, math.Max(radius, point.Accuracy)), filter)
+	at := point.At()
+
+	geofences := make([]models.Geofence, 0, len(intersects))
+	found := make(map[uint64]struct{}, len(intersects))
+
+	for i := 0; i < len(intersects); i++ {
+		gz, isGeozone := intersects[i].(*models.Geofence)
+		if !isGeozone {
+			continue
+		}
+
+		if _, ok := found[gz.PolygonID]; ok {
+			continue
+		}
+
+		found[gz.PolygonID] = struct{}{}
+
+		gzExt, ok := m.geofenceExtCache[gz.PolygonID]
+		if !ok {
+			continue
+		}
+
+		if (userID != nil && *userID != gzExt.UserID) || !gzExt.ActiveAt(at) {
+			continue
+		}
+
+		distance := gzExt.Shape.Nearest(point.Point).Distance
+		inside := gzExt.Shape.Contains(point.Point)
+		containment := classify(inside, distance, point.Accuracy)
+		gap := verticalGap(gzExt, point)
+
+		if gap > 0 {
+			containment = models.ContainmentOutside
+		}
+
+		if containment == models.ContainmentOutside && outsideDistance(inside, distance, gap) > radius {
+			continue
+		}
+
+		// для отобранной геозоны уточняем ближайшую точку границы
+		boundary := nearestBoundary(gzExt.Shape, point.Point)
+
+		res := *gz
+		res.Distance = boundary.Distance
+		res.Containment = containment
+		res.Boundary = &boundary
+		res.Bearing = bearing(point, &boundary)
+
+		switch {
+		case gap > 0:
+			res.Distance = outsideDistance(inside, boundary.Distance, gap)
+			res.VerticalDistance = gap
+		case inside:
+			locateVertical(&res, gzExt, point, true)
+			res.Distance = -res.Distance
+		default:
+			locateVertical(&res, gzExt, point, false)
+		}
+
+		locateOnRoute(&res, gzExt.Shape, point)
+		geofences = append(geofences, res)
+	}
+
+	return geofences
 }
 
 func (m *MemoryGeoCache) PolygonContainsGeo(polygon orb.Polygon, point orb.Point) bool {
@@ -207,3 +411,162 @@ func (m *MemoryGeoCache) PolygonContainsGeo(polygon orb.Polygon, point orb.Point
 
 	return pl.Contains(p)
 }
+
+// locate - положение точки относительно геометрии геозоны с учетом точности её координат.
+// Дистанция до границы рассчитывается, если она запрошена или задана точность координат.
+// Если дистанция запрошена, то возвращается и ближайшая точка границы.
+// Если граница проходит не дальше точности координат, то положение точки не определено.
+func locate(shape geometry.Shape, point models.Point,
+	withDistance bool) (float64, models.Containment, *geometry.Nearest) {
+	var distance float64
+	var boundary *geometry.Nearest
+
+	switch {
+	case withDistance:
+		nearest := nearestBoundary(shape, point.Point)
+		distance, boundary = nearest.Distance, &nearest
+	case point.Accuracy > 0:
+		distance = shape.Nearest(point.Point).Distance
+	}
+
+	return distance, classify(shape.Contains(point.Point), distance, point.Accuracy), boundary
+}
+
+// nearestBoundary - ближайшая к точке точка границы геозоны. Для полигона ищется по геометрии
+// полного разрешения, чтобы индексы полигона, кольца и ребра соответствовали геометрии, которую возвращает GetGeofence.
+func nearestBoundary(shape geometry.Shape, point orb.Point) geometry.Nearest {
+	if polygons, ok := shape.(*geometry.Polygons); ok {
+		return polygons.NearestExact(point)
+	}
+
+	return shape.Nearest(point)
+}
+
+// bearing - азимут из точки на ближайшую точку границы в градусах от 0 до 360 по часовой стрелке от севера.
+func bearing(point models.Point, boundary *geometry.Nearest) float64 {
+	if boundary == nil || boundary.Distance == 0 {
+		return 0
+	}
+
+	return math.Mod(orbgeo.Bearing(point.Point, boundary.Point)+fullCircle, fullCircle)
+}
+
+// locateOnRoute - для геозоны-коридора заполняет расстояние до маршрута и пройденное вдоль него расстояние.
+func locateOnRoute(res *models.Geofence, shape geometry.Shape, point models.Point) {
+	if route, ok := shape.(geometry.Route); ok {
+		res.RouteDistance, res.RouteProgress = route.Locate(point.Point)
+	}
+}
+
+// locateVertical - проверка высоты точки по диапазону высот геозоны. Если высота точки вне диапазона,
+// то возвращает false. Иначе заполняет расстояние до ближайшей границы диапазона, а если рассчитана
+// дистанция до границы геозоны, то она уменьшается до расстояния по вертикали, когда граница диапазона ближе.
+// Для точки без высоты и геозоны без диапазона высот проверка не выполняется.
+func locateVertical(res *models.Geofence, ext *models.GeofenceExt, point models.Point, withDistance bool) bool {
+	if !point.HasAltitude || (ext.MinAltitude == nil && ext.MaxAltitude == nil) {
+		return true
+	}
+
+	vertical := math.Inf(1)
+
+	if ext.MinAltitude != nil {
+		vertical = math.Min(vertical, point.Altitude-*ext.MinAltitude)
+	}
+
+	if ext.MaxAltitude != nil {
+		vertical = math.Min(vertical, *ext.MaxAltitude-point.Altitude)
+	}
+
+	if vertical < 0 {
+		return false
+	}
+
+	res.VerticalDistance = vertical
+
+	if withDistance || point.Accuracy > 0 {
+		res.Distance = math.Min(res.Distance, vertical)
+	}
+
+	return true
+}
+
+// verticalGap - расстояние по вертикали от точки до диапазона высот геозоны, 0 - высота точки в диапазоне
+// или проверка высоты не выполняется.
+func verticalGap(ext *models.GeofenceExt, point models.Point) float64 {
+	if !point.HasAltitude {
+		return 0
+	}
+
+	switch {
+	case ext.MinAltitude != nil && point.Altitude < *ext.MinAltitude:
+		return *ext.MinAltitude - point.Altitude
+	case ext.MaxAltitude != nil && point.Altitude > *ext.MaxAltitude:
+		return point.Altitude - *ext.MaxAltitude
+	default:
+		return 0
+	}
+}
+
+// outsideDistance - расстояние до геозоны от точки снаружи: по горизонтали до границы, если точка вне
+// геозоны на плоскости, и по вертикали gap до диапазона высот.
+func outsideDistance(inside bool, distance, gap float64) float64 {
+	if inside {
+		distance = 0
+	}
+
+	return math.Hypot(distance, gap)
+}
+
+// classify - классификация положения точки по признаку вхождения и расстоянию до границы.
+func classify(inside bool, distance, accuracy float64) models.Containment {
+	switch {
+	case accuracy > 0 && distance <= accuracy:
+		return models.ContainmentBoundaryUncertain
+	case inside:
+		return models.ContainmentInside
+	default:
+		return models.ContainmentOutside
+	}
+}
+
+// search - поиск в rtree пересечений с прямоугольником. Прямоугольник, пересекающий антимеридиан,
+// разбивается на части по обе стороны от него. Геозоны, не удовлетворяющие фильтру, в результат не попадают.
+// Вызывается под блокировкой на чтение.
+func (m *MemoryGeoCache) search(bound orb.Bound, filter *models.Filter) []rtreego.Spatial {
+	bounds := geometry.SplitAntimeridian(bound)
+	filters := m.filters(filter)
+
+	intersects := m.rtree.SearchIntersect(rect(bounds[0]), filters...)
+	for i := 1; i < len(bounds); i++ {
+		intersects = append(intersects, m.rtree.SearchIntersect(rect(bounds[i]), filters...)...)
+	}
+
+	return intersects
+}
+
+// filters - фильтр поиска в rtree по категории, тегам и атрибутам геозоны. Вызывается под блокировкой на чтение.
+func (m *MemoryGeoCache) filters(filter *models.Filter) []rtreego.Filter {
+	if filter.Empty() {
+		return nil
+	}
+
+	return []rtreego.Filter{func(_ []rtreego.Spatial, object rtreego.Spatial) (refuse, abort bool) {
+		gz, isGeozone := object.(*models.Geofence)
+		if !isGeozone {
+			return true, false
+		}
+
+		gzExt, ok := m.geofenceExtCache[gz.PolygonID]
+
+		return !ok || !filter.Match(gzExt), false
+	}}
+}
+
+// rect - прямоугольник rtree по границам геометрии.
+func rect(bound orb.Bound) *rtreego.Rect {
+	r, _ := rtreego.NewRectFromPoints(
+		rtreego.Point{bound.Min.X(), bound.Min.Y()},
+		rtreego.Point{bound.Max.X(), bound.Max.Y()})
+
+	return r
+}
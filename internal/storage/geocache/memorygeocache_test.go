@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
 	"github.com/X-Keeper/geoborder/internal/storage/postgres"
 	"github.com/X-Keeper/geoborder/pkg/logger"
 )
@@ -78,7 +79,7 @@ func TestMemoryGeoCache_FindGeoZoneByPont(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := cache.FindGeofenceByPoint(tt.args.point, &tt.args.userID, true)
+			got, err := cache.FindGeofenceByPoint(models.Point{Point: tt.args.point}, &tt.args.userID, true, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FindGeofenceByPoint() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -118,7 +119,7 @@ func TestMemoryGeoCache_CheckGeofenceByPoint(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := cache.CheckGeofenceByPoint(tt.args.point, tt.args.geofenceID)
+			got, err := cache.CheckGeofenceByPoint(models.Point{Point: tt.args.point}, tt.args.geofenceID, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CheckGeofenceByPoint() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -158,7 +159,7 @@ func TestMemoryGeoCache_GetDistanceToGeofence(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := cache.GetDistanceToGeofence(tt.args.point)
+			got, err := cache.GetDistanceToGeofence(models.Point{Point: tt.args.point}, nil, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FindGeofenceByPoint() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -0,0 +1,155 @@
+package geocache
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// newShapesCache - кэш из геозон exts без подключения к БД.
+func newShapesCache(exts ...*models.GeofenceExt) *MemoryGeoCache {
+	m := &MemoryGeoCache{
+		geofenceExtCache:        make(map[uint64]*models.GeofenceExt),
+		geofenceLinkedToPolygon: make(map[uint64][]uint64),
+		quarantine:              make(map[uint64]models.QuarantinedGeofence),
+		rtree:                   newTree(),
+	}
+
+	for _, ext := range exts {
+		m.insert(ext)
+	}
+
+	return m
+}
+
+func TestMemoryGeoCache_NearbyAltitude(t *testing.T) {
+	minAltitude, maxAltitude := 0.0, 100.0
+
+	m := newShapesCache(&models.GeofenceExt{
+		PolygonID:   1,
+		GeofenceID:  1,
+		Kind:        models.KindCircle,
+		Shape:       geometry.Circle{Center: orb.Point{0, 0}, Radius: 1000},
+		MinAltitude: &minAltitude,
+		MaxAltitude: &maxAltitude,
+	})
+
+	tests := []struct {
+		name        string
+		altitude    float64
+		radius      float64
+		found       bool
+		containment models.Containment
+		distance    float64
+	}{
+		{name: "inside band", altitude: 70, radius: 10, found: true, containment: models.ContainmentInside, distance: -30},
+		{name: "above band", altitude: 150, radius: 100, found: true, containment: models.ContainmentOutside, distance: 50},
+		{name: "above band out of radius", altitude: 150, radius: 10},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			point := models.Point{Point: orb.Point{0, 0}, Altitude: tt.altitude, HasAltitude: true}
+
+			want := 0
+			if tt.found {
+				want = 1
+			}
+
+			got, _ := m.FindNearbyGeofences(point, tt.radius, nil, nil)
+			if len(got) != want {
+				t.Fatalf("FindNearbyGeofences() = %v, found %v", got, tt.found)
+			}
+
+			if tt.found && (got[0].Containment != tt.containment || math.Abs(got[0].Distance-tt.distance) > 1e-6) {
+				t.Errorf("FindNearbyGeofences() = %v %v, want %v %v",
+					got[0].Containment, got[0].Distance, tt.containment, tt.distance)
+			}
+
+			// положение совпадает с результатом поиска вхождения точки
+			byPoint, _ := m.FindGeofenceByPoint(point, nil, false, nil)
+			if inside := tt.found && tt.containment == models.ContainmentInside; inside != (len(byPoint) == 1) {
+				t.Errorf("FindGeofenceByPoint() = %v, want inside %v", byPoint, inside)
+			}
+		})
+	}
+}
+
+func TestMemoryGeoCache_FindNearestGeofences(t *testing.T) {
+	circle := func(polygonID, geofenceID, userID uint64, lon float64) *models.GeofenceExt {
+		return &models.GeofenceExt{
+			PolygonID:  polygonID,
+			GeofenceID: geofenceID,
+			UserID:     userID,
+			Kind:       models.KindCircle,
+			Shape:      geometry.Circle{Center: orb.Point{lon, 0}, Radius: 1000},
+		}
+	}
+
+	// геозона 2 состоит из двух полигонов, геозона 4 принадлежит другому пользователю
+	m := newShapesCache(
+		circle(1, 1, 1, 0),
+		circle(2, 2, 1, 1),
+		circle(3, 2, 1, 90),
+		circle(4, 3, 1, 2),
+		circle(5, 4, 2, 0.5),
+		circle(6, 5, 1, -150),
+	)
+
+	userID := uint64(1)
+
+	tests := []struct {
+		name        string
+		limit       int
+		maxDistance float64
+		want        []uint64
+	}{
+		{name: "nearest", limit: 2, want: []uint64{1, 2}},
+		{name: "all", limit: 10, want: []uint64{1, 2, 3, 5}},
+		{name: "nearest three", limit: 3, want: []uint64{1, 2, 3}},
+		{name: "max distance", limit: 10, maxDistance: 300000, want: []uint64{1, 2, 3}},
+	}
+
+	t.Parallel()
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := m.FindNearestGeofences(models.Point{Point: orb.Point{0.005, 0}}, &userID, tt.limit,
+				tt.maxDistance, nil)
+			if err != nil {
+				t.Fatalf("FindNearestGeofences() error = %v", err)
+			}
+
+			ids := make([]uint64, 0, len(got))
+			for i := range got {
+				ids = append(ids, got[i].GeofenceID)
+			}
+
+			if len(ids) != len(tt.want) {
+				t.Fatalf("FindNearestGeofences() = %v, want %v", ids, tt.want)
+			}
+
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("FindNearestGeofences() = %v, want %v", ids, tt.want)
+				}
+			}
+
+			if got[0].Distance >= 0 || got[0].Containment != models.ContainmentInside {
+				t.Errorf("FindNearestGeofences() nearest = %v %v, want inside", got[0].Containment, got[0].Distance)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package geocache
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dhconnelly/rtreego"
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+const (
+	// половина длины экватора - дальше искать некуда
+	nearestMaxRadius = math.Pi * orb.EarthRadius
+	// запас к радиусу поиска в метрах: геозона на границе радиуса касается прямоугольника поиска
+	// и может не попасть в него из-за погрешности вычислений
+	nearestRadiusMargin = 1.0
+)
+
+// FindNearestGeofences - поиск limit ближайших к точке геозон, упорядоченных по расстоянию до границы.
+// Геозоны, в которые попадает точка, считаются находящимися на нулевом расстоянии и идут первыми,
+// дистанция для них возвращается отрицательной. Если maxDistance > 0, то геозоны дальше maxDistance
+// метров не возвращаются.
+// Поиск выполняется в два прохода: обход rtree от ближайших прямоугольников отбирает limit геозон,
+// точное расстояние до самой дальней из них ограничивает радиус, в котором затем однократно
+// рассчитывается расстояние до границы всех геозон - геозоны вне радиуса гарантированно дальше отобранных.
+func (m *MemoryGeoCache) FindNearestGeofences(point models.Point, userID *uint64, limit int,
+	maxDistance float64, filter *models.Filter) ([]models.Geofence, error) {
+	if limit <= 0 {
+		return []models.Geofence{}, nil
+	}
+
+	if maxDistance <= 0 || maxDistance > nearestMaxRadius {
+		maxDistance = nearestMaxRadius
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	radius := math.Min(m.nearestBound(point, userID, limit, filter)+nearestRadiusMargin, maxDistance)
+
+	geofences := nearestByGeofence(m.nearby(point, radius, userID, filter))
+	if len(geofences) > limit {
+		geofences = geofences[:limit]
+	}
+
+	return geofences, nil
+}
+
+// nearestBound - расстояние в метрах, в пределах которого гарантированно находятся limit ближайших к точке геозон,
+// бесконечность - геозон меньше limit. Кандидаты отбираются обходом rtree по расстоянию до прямоугольников,
+// по одному полигону на геозону, для них рассчитывается точное расстояние. Вызывается под блокировкой на чтение.
+func (m *MemoryGeoCache) nearestBound(point models.Point, userID *uint64, limit int, filter *models.Filter) float64 {
+	at := point.At()
+
+	eligible := func(results []rtreego.Spatial, object rtreego.Spatial) (refuse, abort bool) {
+		gz, isGeozone := object.(*models.Geofence)
+		if !isGeozone {
+			return true, false
+		}
+
+		gzExt, ok := m.geofenceExtCache[gz.PolygonID]
+		if !ok || (userID != nil && *userID != gzExt.UserID) || !gzExt.ActiveAt(at) {
+			return true, false
+		}
+
+		for i := range results {
+			if found, ok := results[i].(*models.Geofence); ok && found.GeofenceID == gz.GeofenceID {
+				return true, false
+			}
+		}
+
+		return false, false
+	}
+
+	candidates := m.rtree.NearestNeighbors(limit, rtreego.Point{point.X(), point.Y()},
+		append(m.filters(filter), eligible)...)
+	if len(candidates) < limit {
+		return math.Inf(1)
+	}
+
+	bound := 0.0
+
+	for i := range candidates {
+		gz, _ := candidates[i].(*models.Geofence)
+		gzExt := m.geofenceExtCache[gz.PolygonID]
+
+		distance := outsideDistance(gzExt.Shape.Contains(point.Point), gzExt.Shape.Nearest(point.Point).Distance,
+			verticalGap(gzExt, point))
+		bound = math.Max(bound, distance)
+	}
+
+	return bound
+}
+
+// nearestByGeofence - оставляет для каждой геозоны ближайший полигон и упорядочивает результат по расстоянию до границы.
+func nearestByGeofence(found []models.Geofence) []models.Geofence {
+	byGeofence := make(map[uint64]models.Geofence, len(found))
+
+	for i := 0; i < len(found); i++ {
+		if v, ok := byGeofence[found[i].GeofenceID]; !ok || found[i].Distance < v.Distance {
+			byGeofence[found[i].GeofenceID] = found[i]
+		}
+	}
+
+	geofences := make([]models.Geofence, 0, len(byGeofence))
+	for _, v := range byGeofence {
+		geofences = append(geofences, v)
+	}
+
+	sort.Slice(geofences, func(i, j int) bool {
+		di, dj := math.Max(geofences[i].Distance, 0), math.Max(geofences[j].Distance, 0)
+		if di != dj {
+			return di < dj
+		}
+
+		if geofences[i].Distance != geofences[j].Distance {
+			return geofences[i].Distance < geofences[j].Distance
+		}
+
+		return geofences[i].GeofenceID < geofences[j].GeofenceID
+	})
+
+	return geofences
+}
@@ -0,0 +1,30 @@
+package geocache
+
+import (
+	"sort"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// GetQuarantine - полигоны геозон, не загруженные в кэш из-за неисправимых дефектов геометрии,
+// упорядоченные по id полигона.
+func (m *MemoryGeoCache) GetQuarantine(userID *uint64) ([]models.QuarantinedGeofence, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	geofences := make([]models.QuarantinedGeofence, 0, len(m.quarantine))
+
+	for _, q := range m.quarantine {
+		if userID != nil && *userID != q.UserID {
+			continue
+		}
+
+		geofences = append(geofences, q)
+	}
+
+	sort.Slice(geofences, func(i, j int) bool {
+		return geofences[i].PolygonID < geofences[j].PolygonID
+	})
+
+	return geofences, nil
+}
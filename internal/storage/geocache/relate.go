@@ -0,0 +1,78 @@
+package geocache
+
+import (
+	"sort"
+
+	"github.com/paulmach/orb"
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// FindGeofencesByPolygon - поиск геозон, которые пересекает, содержит или внутри которых лежит полигон query.
+// Кандидаты отбираются в rtree по прямоугольникам полигонов запроса. Результат упорядочен по убыванию
+// площади пересечения.
+func (m *MemoryGeoCache) FindGeofencesByPolygon(query orb.Geometry, userID *uint64,
+	filter *models.Filter) ([]models.PolygonRelation, error) {
+	polygons, ok := geometry.NewPolygons(query)
+	if !ok || len(polygons.Members) == 0 {
+		return nil, errors.New("polygon or multipolygon expected")
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	relations := make([]models.PolygonRelation, 0)
+	found := make(map[uint64]struct{})
+
+	for _, bound := range polygons.Bounds() {
+		intersects := m.search(bound, filter)
+
+		for i := 0; i < len(intersects); i++ {
+			gz, isGeozone := intersects[i].(*models.Geofence)
+			if !isGeozone {
+				continue
+			}
+
+			if _, ok = found[gz.PolygonID]; ok {
+				continue
+			}
+
+			found[gz.PolygonID] = struct{}{}
+
+			gzExt, ok := m.geofenceExtCache[gz.PolygonID]
+			if !ok {
+				continue
+			}
+
+			if userID != nil && *userID != gzExt.UserID {
+				continue
+			}
+
+			relation, area := geometry.Relate(polygons, gzExt.Shape)
+			if relation == geometry.RelationDisjoint {
+				continue
+			}
+
+			relations = append(relations, models.PolygonRelation{
+				PolygonID:   gzExt.PolygonID,
+				GeofenceID:  gzExt.GeofenceID,
+				UserID:      gzExt.UserID,
+				Title:       gzExt.Title,
+				Relation:    relation,
+				OverlapArea: area,
+			})
+		}
+	}
+
+	sort.Slice(relations, func(i, j int) bool {
+		if relations[i].OverlapArea != relations[j].OverlapArea {
+			return relations[i].OverlapArea > relations[j].OverlapArea
+		}
+
+		return relations[i].PolygonID < relations[j].PolygonID
+	})
+
+	return relations, nil
+}
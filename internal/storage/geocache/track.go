@@ -0,0 +1,186 @@
+package geocache
+
+import (
+	"math"
+	"sort"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// minTrackInterval - минимальная длина участка отрезка трека между пересечениями с границей, в долях отрезка.
+// Более короткие участки возникают при прохождении трека через вершину полигона и не рассматриваются.
+const minTrackInterval = 1e-9
+
+// FindTrackCrossings - поиск геозон, через которые прошел трек. Точки трека упорядочены по времени.
+// Кандидаты отбираются в rtree по прямоугольникам отрезков трека, затем для каждого отрезка ищутся
+// пересечения с границей геозоны, поэтому в результат попадают и геозоны, внутри которых
+// не оказалось ни одной точки трека. Результат упорядочен по времени входа.
+func (m *MemoryGeoCache) FindTrackCrossings(track []models.Point, userID *uint64,
+	filter *models.Filter) ([]models.Crossing, error) {
+	if len(track) == 0 {
+		return nil, nil
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	// отрезки трека, прямоугольники которых пересекают прямоугольник полигона, key - id полигона
+	candidates := make(map[uint64][]int)
+	order := make([]uint64, 0)
+
+	for i := 0; i < segmentCount(track); i++ {
+		a, b := trackSegment(track, i)
+		intersects := m.search(orb.MultiPoint{a, b}.Bound(), filter)
+
+		for j := 0; j < len(intersects); j++ {
+			gz, isGeozone := intersects[j].(*models.Geofence)
+			if !isGeozone {
+				continue
+			}
+
+			segments, ok := candidates[gz.PolygonID]
+			if !ok {
+				order = append(order, gz.PolygonID)
+			}
+
+			// полигон мультиполигона может попасть в выборку несколько раз
+			if len(segments) == 0 || segments[len(segments)-1] != i {
+				candidates[gz.PolygonID] = append(segments, i)
+			}
+		}
+	}
+
+	crossings := make([]models.Crossing, 0, len(order))
+
+	for _, polygonID := range order {
+		gzExt, ok := m.geofenceExtCache[polygonID]
+		if !ok {
+			continue
+		}
+
+		if userID != nil && *userID != gzExt.UserID {
+			continue
+		}
+
+		// прохождение учитывается, если геозона действует в момент входа
+		for _, crossing := range visits(gzExt, track, candidates[polygonID]) {
+			if gzExt.ActiveAt(models.Point{Time: crossing.Entry.Time}.At()) {
+				crossings = append(crossings, crossing)
+			}
+		}
+	}
+
+	sort.Slice(crossings, func(i, j int) bool {
+		if crossings[i].Entry.Time != crossings[j].Entry.Time {
+			return crossings[i].Entry.Time < crossings[j].Entry.Time
+		}
+
+		return crossings[i].PolygonID < crossings[j].PolygonID
+	})
+
+	return crossings, nil
+}
+
+// visits - прохождения трека через полигон геозоны по отрезкам трека segments.
+// Каждый отрезок делится точками пересечения с границей на участки, вхождение участка
+// определяется по его середине.
+func visits(ext *models.GeofenceExt, track []models.Point, segments []int) []models.Crossing {
+	var res []models.Crossing
+	var open *models.Crossing
+
+	last := segmentCount(track) - 1
+
+	for _, i := range segments {
+		a, b := trackSegment(track, i)
+		ts := append([]float64{0}, ext.Shape.Crossings(a, b)...)
+		ts = append(ts, 1)
+		sort.Float64s(ts)
+
+		for k := 0; k < len(ts)-1; k++ {
+			if ts[k+1]-ts[k] < minTrackInterval {
+				continue
+			}
+
+			inside := ext.Shape.Contains(geometry.NormalizePoint(interpolate(a, b, (ts[k]+ts[k+1])/2))) // nolint:gomnd // середина
+
+			switch {
+			case inside && open == nil:
+				open = &models.Crossing{
+					PolygonID:     ext.PolygonID,
+					GeofenceID:    ext.GeofenceID,
+					UserID:        ext.UserID,
+					Title:         ext.Title,
+					Entry:         trackPoint(track, i, ts[k]),
+					StartedInside: i == 0 && ts[k] == 0,
+				}
+			case !inside && open != nil:
+				open.Exit = trackPoint(track, i, ts[k])
+				res = append(res, *open)
+				open = nil
+			}
+		}
+
+		// геозона покинута на отрезке, прямоугольник которого её не пересекает
+		if open != nil && i != last && !contains(segments, i+1) {
+			open.Exit = trackPoint(track, i, 1)
+			res = append(res, *open)
+			open = nil
+		}
+	}
+
+	if open != nil {
+		open.Exit = trackPoint(track, last, 1)
+		open.EndedInside = true
+		res = append(res, *open)
+	}
+
+	return res
+}
+
+// segmentCount - количество отрезков трека. Трек из одной точки рассматривается как отрезок нулевой длины.
+func segmentCount(track []models.Point) int {
+	if len(track) < 2 { // nolint:gomnd // отрезок из двух точек
+		return 1
+	}
+
+	return len(track) - 1
+}
+
+// trackSegment - концы отрезка трека с индексом i, долгота конца может выходить за пределы [-180, 180],
+// если отрезок пересекает антимеридиан.
+func trackSegment(track []models.Point, i int) (a, b orb.Point) {
+	j := i + 1
+	if j >= len(track) {
+		j = len(track) - 1
+	}
+
+	return track[i].Point, geometry.UnwrapSegment(track[i].Point, track[j].Point)
+}
+
+// trackPoint - точка на отрезке трека с индексом i с параметром t, время интерполируется линейно.
+func trackPoint(track []models.Point, i int, t float64) models.TrackPoint {
+	a, b := trackSegment(track, i)
+	start, end := track[i].Time, track[len(track)-1].Time
+
+	if i+1 < len(track) {
+		end = track[i+1].Time
+	}
+
+	return models.TrackPoint{
+		Point: geometry.NormalizePoint(interpolate(a, b, t)),
+		Time:  start + int64(math.Round(t*float64(end-start))),
+	}
+}
+
+func interpolate(a, b orb.Point, t float64) orb.Point {
+	return orb.Point{a[0] + (b[0]-a[0])*t, a[1] + (b[1]-a[1])*t}
+}
+
+func contains(segments []int, i int) bool {
+	n := sort.SearchInts(segments, i)
+
+	return n < len(segments) && segments[n] == i
+}
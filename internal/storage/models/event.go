@@ -0,0 +1,91 @@
+package models
+
+// EventType - вид перехода устройства относительно геозоны.
+type EventType string
+
+const (
+	// EventEnter - устройство вошло в геозону.
+	EventEnter EventType = "enter"
+	// EventExit - устройство вышло из геозоны.
+	EventExit EventType = "exit"
+	// EventInside - устройство осталось в геозоне.
+	EventInside EventType = "inside"
+	// EventDwell - устройство находится в геозоне дольше заданного времени.
+	EventDwell EventType = "dwell"
+)
+
+// Event - переход устройства относительно геозоны, определенный по очередной точке устройства.
+// Для выхода полигон и название геозоны берутся из последнего состояния, в котором устройство было внутри.
+type Event struct {
+	// Sequence - номер события в шине событий или в исходящей очереди получателя, 0 - событие не опубликовано
+	Sequence   uint64
+	DeviceID   uint64
+	GeofenceID uint64
+	PolygonID  uint64
+	UserID     uint64
+	Title      string
+	Type       EventType
+	// Point - точка устройства, по которой определен переход
+	Point TrackPoint
+}
+
+// EventFilter - условия отбора событий подписчиком. Пустой список не ограничивает выборку, значения внутри
+// списка объединяются по ИЛИ, списки между собой - по И.
+type EventFilter struct {
+	UserIDs     []uint64
+	GeofenceIDs []uint64
+	DeviceIDs   []uint64
+}
+
+// Match - событие удовлетворяет фильтру. Пустой фильтр (в том числе nil) пропускает все события.
+func (f *EventFilter) Match(e *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	return matchID(f.UserIDs, e.UserID) && matchID(f.GeofenceIDs, e.GeofenceID) && matchID(f.DeviceIDs, e.DeviceID)
+}
+
+func matchID(ids []uint64, id uint64) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EventMessage - переход устройства в формате JSON для внешних систем.
+type EventMessage struct {
+	Sequence   uint64  `json:"sequence"`
+	Type       string  `json:"type"`
+	DeviceID   uint64  `json:"device_id"`
+	UserID     uint64  `json:"user_id"`
+	GeofenceID uint64  `json:"geofence_id"`
+	PolygonID  uint64  `json:"polygon_id"`
+	Title      string  `json:"title"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	// Time - время точки перехода, unix time в миллисекундах
+	Time int64 `json:"time"`
+}
+
+func NewEventMessage(e Event) EventMessage {
+	return EventMessage{
+		Sequence:   e.Sequence,
+		Type:       string(e.Type),
+		DeviceID:   e.DeviceID,
+		UserID:     e.UserID,
+		GeofenceID: e.GeofenceID,
+		PolygonID:  e.PolygonID,
+		Title:      e.Title,
+		Latitude:   e.Point.Lat(),
+		Longitude:  e.Point.Lon(),
+		Time:       e.Point.Time,
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+// Filter - условия отбора геозон по категории, тегам и атрибутам. Пустое условие не ограничивает отбор,
+// nil - геозоны не фильтруются.
+type Filter struct {
+	// Categories - категория геозоны входит в список
+	Categories []string
+	// Tags - геозона отмечена всеми тегами из списка
+	Tags []string
+	// Attributes - значения атрибутов геозоны совпадают с заданными
+	Attributes map[string]string
+}
+
+// Empty - фильтр не содержит условий.
+func (f *Filter) Empty() bool {
+	return f == nil || (len(f.Categories) == 0 && len(f.Tags) == 0 && len(f.Attributes) == 0)
+}
+
+// Match - геозона удовлетворяет всем условиям фильтра.
+func (f *Filter) Match(g *GeofenceExt) bool {
+	if f.Empty() {
+		return true
+	}
+
+	if len(f.Categories) > 0 && !contains(f.Categories, g.Category) {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !contains(g.Tags, tag) {
+			return false
+		}
+	}
+
+	for key, value := range f.Attributes {
+		if v, ok := g.Attributes[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// contains - список values содержит значение value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
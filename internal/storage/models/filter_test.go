@@ -0,0 +1,47 @@
+package models
+
+import "testing"
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	geofence := &GeofenceExt{
+		Category:   "depot",
+		Tags:       []string{"night", "fuel"},
+		Attributes: map[string]string{"region": "south"},
+	}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   bool
+	}{
+		{name: "nil filter", filter: nil, want: true},
+		{name: "category in list", filter: &Filter{Categories: []string{"customer", "depot"}}, want: true},
+		{name: "category not in list", filter: &Filter{Categories: []string{"customer"}}, want: false},
+		{name: "all tags", filter: &Filter{Tags: []string{"fuel", "night"}}, want: true},
+		{name: "missing tag", filter: &Filter{Tags: []string{"fuel", "wash"}}, want: false},
+		{name: "attribute", filter: &Filter{Attributes: map[string]string{"region": "south"}}, want: true},
+		{name: "attribute value", filter: &Filter{Attributes: map[string]string{"region": "north"}}, want: false},
+		{name: "missing attribute", filter: &Filter{Attributes: map[string]string{"zone": "south"}}, want: false},
+		{
+			name: "category and attribute",
+			filter: &Filter{
+				Categories: []string{"depot", "customer"},
+				Attributes: map[string]string{"region": "south"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.filter.Match(geofence); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dhconnelly/rtreego"
+	"github.com/paulmach/orb"
 	"github.com/paulmach/orb/geojson"
+
+	"github.com/X-Keeper/geoborder/internal/geometry"
 )
 
 type Geofence struct {
@@ -14,6 +18,23 @@ type Geofence struct {
 	Title       string `json:"title"`
 	Distance    float64
 	BoundingBox *rtreego.Rect
+	// положение точки запроса относительно геозоны
+	Containment Containment `json:"-"`
+	// для геозоны-коридора: расстояние от точки до линии маршрута и пройденное вдоль маршрута расстояние, в метрах
+	RouteDistance float64 `json:"-"`
+	RouteProgress float64 `json:"-"`
+	// для геозоны с диапазоном высот: расстояние от точки до ближайшей границы диапазона, в метрах
+	VerticalDistance float64 `json:"-"`
+	// ближайшая к точке запроса точка границы геозоны и азимут на неё из точки запроса в градусах
+	// по часовой стрелке от севера, заполняются при расчете дистанции
+	Boundary *geometry.Nearest `json:"-"`
+	Bearing  float64           `json:"-"`
+	// индекс полигона в составе мультиполигона, описывающего геозону
+	MemberIndex int `json:"-"`
+	// категория, теги и атрибуты геозоны
+	Category   string            `json:"-"`
+	Tags       []string          `json:"-"`
+	Attributes map[string]string `json:"-"`
 }
 
 func (t Geofence) Bounds() *rtreego.Rect {
@@ -24,13 +45,90 @@ func (t Geofence) String() string {
 	return fmt.Sprintf(" Geofence : %s, distanse = %f ", t.Title, t.Distance)
 }
 
+// GeofenceKind - вид геометрии геозоны.
+type GeofenceKind string
+
+const (
+	// KindPolygon - геозона описана полигоном или мультиполигоном.
+	KindPolygon GeofenceKind = "polygon"
+	// KindCircle - геозона описана центром и радиусом.
+	KindCircle GeofenceKind = "circle"
+	// KindCorridor - геозона описана маршрутом и шириной коридора вдоль него.
+	KindCorridor GeofenceKind = "corridor"
+)
+
 type GeofenceExt struct {
-	PolygonID           uint64            `json:"polygonId"`
-	GeofenceID          uint64            `json:"geofenceId"`
-	Title               string            `json:"title"`
-	UserID              uint64            `json:"userId"`
-	GeometryFull        *geojson.Geometry `json:"geometryFull"`
-	GeometrySimplify    geojson.Geometry  `json:"geometrySimplify"`
-	GeometryBoundingBox string            `json:"geometryBoundingBox"`
-	BoundingBox         *rtreego.Rect
+	PolygonID        uint64            `json:"polygonId"`
+	GeofenceID       uint64            `json:"geofenceId"`
+	Title            string            `json:"title"`
+	UserID           uint64            `json:"userId"`
+	Kind             GeofenceKind      `json:"kind"`
+	GeometryFull     *geojson.Geometry `json:"geometryFull"`
+	GeometrySimplify geojson.Geometry  `json:"geometrySimplify"`
+	// центр и радиус в метрах для круглой геозоны
+	Center *geojson.Geometry `json:"center"`
+	Radius float64           `json:"radius"`
+	// маршрут и ширина коридора в метрах для геозоны-коридора
+	Route *geojson.Geometry `json:"route"`
+	Width float64           `json:"width"`
+	// диапазон высот в метрах, в котором действует геозона, nil - без ограничения
+	MinAltitude *float64 `json:"minAltitude"`
+	MaxAltitude *float64 `json:"maxAltitude"`
+	// расписания, в которые действует геозона, пусто - действует всегда
+	Schedules []Schedule `json:"schedules"`
+	// категория, теги и атрибуты геозоны, по которым отбираются геозоны в запросах
+	Category    string            `json:"category"`
+	Tags        []string          `json:"tags"`
+	Attributes  map[string]string `json:"attributes"`
+	BoundingBox *rtreego.Rect
+	// геометрия, по которой выполняется поиск
+	Shape geometry.Shape `json:"-"`
+	// дефекты геометрии, найденные при загрузке
+	Issues []geometry.Issue `json:"-"`
+	// причина, по которой геозона помещена в карантин и не участвует в поиске, пусто - геозона исправна
+	QuarantineReason string `json:"-"`
+}
+
+// GeofenceGeometry - геометрия полигона геозоны для отображения на карте. Для полигона - упрощенный
+// полигон (мультиполигон), для круга - центр и радиус, для коридора - линия маршрута и ширина коридора.
+type GeofenceGeometry struct {
+	PolygonID  uint64
+	GeofenceID uint64
+	UserID     uint64
+	Title      string
+	Kind       GeofenceKind
+	Geometry   orb.Geometry
+	Radius     float64
+	Width      float64
+}
+
+// GeofenceDetails - геометрия полигона геозоны полного разрешения и её геодезические характеристики.
+type GeofenceDetails struct {
+	GeofenceGeometry
+	Measures geometry.Measures
+}
+
+// ActiveAt - геозона действует в момент t хотя бы по одному из расписаний.
+func (g *GeofenceExt) ActiveAt(t time.Time) bool {
+	if len(g.Schedules) == 0 {
+		return true
+	}
+
+	for i := range g.Schedules {
+		if g.Schedules[i].Active(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QuarantinedGeofence - полигон геозоны, геометрия которого содержит неисправимые дефекты.
+type QuarantinedGeofence struct {
+	PolygonID  uint64
+	GeofenceID uint64
+	UserID     uint64
+	Title      string
+	Reason     string
+	Issues     []geometry.Issue
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/paulmach/orb"
+)
+
+// Point - геоточка запроса.
+type Point struct {
+	orb.Point
+	// Accuracy - точность определения координат, в метрах. Точка рассматривается как круг такого радиуса
+	Accuracy float64
+	// Time - время фиксации координат, unix time в миллисекундах
+	Time int64
+	// Altitude - высота в метрах, учитывается, если HasAltitude
+	Altitude    float64
+	HasAltitude bool
+}
+
+// At - момент фиксации координат, если время не задано - текущий момент.
+func (p Point) At() time.Time {
+	if p.Time == 0 {
+		return time.Now()
+	}
+
+	return time.Unix(0, p.Time*int64(time.Millisecond))
+}
+
+// Containment - положение точки относительно геозоны.
+type Containment int
+
+const (
+	// ContainmentInside - точка внутри геозоны.
+	ContainmentInside Containment = iota
+	// ContainmentOutside - точка вне геозоны.
+	ContainmentOutside
+	// ContainmentBoundaryUncertain - граница геозоны находится в пределах точности координат точки.
+	ContainmentBoundaryUncertain
+)
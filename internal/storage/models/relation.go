@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/X-Keeper/geoborder/internal/geometry"
+)
+
+// PolygonRelation - отношение полигона запроса к полигону геозоны и площадь их пересечения, в квадратных метрах.
+type PolygonRelation struct {
+	PolygonID   uint64
+	GeofenceID  uint64
+	UserID      uint64
+	Title       string
+	Relation    geometry.Relation
+	OverlapArea float64
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	timeOfDayLayout = "15:04:05"
+	dateLayout      = "2006-01-02"
+	secondsPerDay   = 24 * 60 * 60
+)
+
+// Schedule - расписание, в которое действует геозона: дни недели, интервал времени суток в часовом поясе
+// и диапазон дат. Интервал, конец которого меньше начала, переходит через полночь и относится к дню недели
+// и дате своего начала. Пустые поля не ограничивают расписание.
+type Schedule struct {
+	// DaysOfWeek - дни недели, 0 - воскресенье
+	DaysOfWeek []int   `json:"daysOfWeek"`
+	StartTime  string  `json:"startTime"`
+	EndTime    string  `json:"endTime"`
+	TimeZone   string  `json:"timeZone"`
+	StartDate  *string `json:"startDate"`
+	EndDate    *string `json:"endDate"`
+
+	location   *time.Location
+	days       uint8
+	start, end int
+	from, to   time.Time
+}
+
+// Compile - разбор полей расписания. Вызывается один раз при загрузке геозоны.
+func (s *Schedule) Compile() error {
+	var err error
+
+	if s.location, err = time.LoadLocation(s.TimeZone); err != nil {
+		return errors.Wrap(err, "invalid schedule time zone")
+	}
+
+	s.days = 0
+	for _, d := range s.DaysOfWeek {
+		if d < int(time.Sunday) || d > int(time.Saturday) {
+			return errors.Errorf("invalid schedule day of week %d", d)
+		}
+
+		s.days |= 1 << d
+	}
+
+	if s.start, err = timeOfDay(s.StartTime, 0); err != nil {
+		return err
+	}
+
+	if s.end, err = timeOfDay(s.EndTime, secondsPerDay); err != nil {
+		return err
+	}
+
+	if s.from, err = date(s.StartDate, s.location); err != nil {
+		return err
+	}
+
+	if s.to, err = date(s.EndDate, s.location); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Active - расписание действует в момент t.
+func (s *Schedule) Active(t time.Time) bool {
+	local := t.In(s.location)
+	seconds := local.Hour()*3600 + local.Minute()*60 + local.Second() // nolint:gomnd // секунды от начала суток
+
+	switch {
+	case s.start < s.end:
+		return seconds >= s.start && seconds < s.end && s.activeDay(local)
+	case seconds >= s.start:
+		return s.activeDay(local)
+	case seconds < s.end:
+		// продолжение интервала, начавшегося накануне
+		return s.activeDay(local.AddDate(0, 0, -1))
+	default:
+		return false
+	}
+}
+
+// activeDay - день начала интервала попадает в дни недели и диапазон дат расписания.
+func (s *Schedule) activeDay(local time.Time) bool {
+	if s.days != 0 && s.days&(1<<local.Weekday()) == 0 {
+		return false
+	}
+
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.location)
+
+	return (s.from.IsZero() || !day.Before(s.from)) && (s.to.IsZero() || !day.After(s.to))
+}
+
+// timeOfDay - время суток в секундах, для пустой строки - def.
+func timeOfDay(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid schedule time %q", value)
+	}
+
+	return t.Hour()*3600 + t.Minute()*60 + t.Second(), nil // nolint:gomnd // секунды от начала суток
+}
+
+// date - начало дня в часовом поясе расписания, для пустого значения - нулевое время.
+func date(value *string, location *time.Location) (time.Time, error) {
+	if value == nil || *value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(dateLayout, *value, location)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid schedule date %q", *value)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleActive(t *testing.T) {
+	t.Parallel()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	startDate, endDate := "2021-06-01", "2021-06-30"
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		at       time.Time
+		want     bool
+	}{
+		{
+			name:     "working hours",
+			schedule: Schedule{DaysOfWeek: []int{1, 2, 3, 4, 5}, StartTime: "09:00:00", EndTime: "18:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 7, 10, 0, 0, 0, moscow), // понедельник
+			want:     true,
+		},
+		{
+			name:     "end of window is excluded",
+			schedule: Schedule{StartTime: "09:00:00", EndTime: "18:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 7, 18, 0, 0, 0, moscow),
+			want:     false,
+		},
+		{
+			name:     "weekend",
+			schedule: Schedule{DaysOfWeek: []int{1, 2, 3, 4, 5}, StartTime: "09:00:00", EndTime: "18:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 6, 10, 0, 0, 0, moscow), // воскресенье
+			want:     false,
+		},
+		{
+			name:     "time zone",
+			schedule: Schedule{StartTime: "09:00:00", EndTime: "18:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 7, 7, 0, 0, 0, time.UTC), // 10:00 по Москве
+			want:     true,
+		},
+		{
+			name:     "overnight window belongs to start day",
+			schedule: Schedule{DaysOfWeek: []int{5}, StartTime: "22:00:00", EndTime: "06:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 12, 3, 0, 0, 0, moscow), // суббота, интервал начался в пятницу
+			want:     true,
+		},
+		{
+			name:     "overnight window of inactive day",
+			schedule: Schedule{DaysOfWeek: []int{5}, StartTime: "22:00:00", EndTime: "06:00:00", TimeZone: "Europe/Moscow"},
+			at:       time.Date(2021, 6, 11, 3, 0, 0, 0, moscow), // пятница, интервал начался в четверг
+			want:     false,
+		},
+		{
+			name:     "date range",
+			schedule: Schedule{TimeZone: "Europe/Moscow", StartDate: &startDate, EndDate: &endDate},
+			at:       time.Date(2021, 6, 30, 23, 0, 0, 0, moscow),
+			want:     true,
+		},
+		{
+			name:     "after date range",
+			schedule: Schedule{TimeZone: "Europe/Moscow", StartDate: &startDate, EndDate: &endDate},
+			at:       time.Date(2021, 7, 1, 0, 0, 0, 0, moscow),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.schedule.Compile(); err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+
+			if got := tt.schedule.Active(tt.at); got != tt.want {
+				t.Errorf("Active() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleCompileErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+	}{
+		{name: "unknown time zone", schedule: Schedule{TimeZone: "Mars/Olympus"}},
+		{name: "day of week out of range", schedule: Schedule{DaysOfWeek: []int{7}}},
+		{name: "invalid time", schedule: Schedule{StartTime: "25:00"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.schedule.Compile(); err == nil {
+				t.Error("Compile() error = nil, want error")
+			}
+		})
+	}
+}
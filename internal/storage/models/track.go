@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/paulmach/orb"
+)
+
+// TrackPoint - точка трека с временем фиксации, unix time в миллисекундах.
+type TrackPoint struct {
+	orb.Point
+	Time int64
+}
+
+// Crossing - прохождение трека через геозону: точки и время входа в полигон геозоны и выхода из него.
+// Точки входа и выхода интерполируются на границе полигона между соседними точками трека.
+type Crossing struct {
+	PolygonID  uint64
+	GeofenceID uint64
+	UserID     uint64
+	Title      string
+	Entry      TrackPoint
+	Exit       TrackPoint
+	// StartedInside - трек начинается внутри геозоны, точка входа - первая точка трека
+	StartedInside bool
+	// EndedInside - трек заканчивается внутри геозоны, точка выхода - последняя точка трека
+	EndedInside bool
+}
@@ -2,21 +2,58 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/dhconnelly/rtreego"
 	"github.com/jackc/pgx/v4"
 	"github.com/paulmach/orb"
-	"github.com/paulmach/orb/encoding/wkb"
+	"github.com/paulmach/orb/geojson"
 	"github.com/pkg/errors"
 
 	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/geometry"
 	"github.com/X-Keeper/geoborder/internal/storage/models"
 	"github.com/X-Keeper/geoborder/pkg/logger"
 )
 
+// selectGeofenceExt - выборка расширенного описания геозоны. Строка geo.gz_polygon описывает
+// либо полигон (мультиполигон) геозоны, либо круг с центром center и радиусом radius в метрах,
+// либо коридор вдоль линии маршрута route шириной width метров. Если заданы min_altitude или max_altitude,
+// то геозона действует только в этом диапазоне высот, в метрах. Расписания geo.gz_schedule ограничивают
+// время, в которое действует геозона. Категория, теги и атрибуты геозоны используются для отбора геозон
+// в запросах, значения атрибутов приводятся к строкам.
+const selectGeofenceExt = "SELECT json_build_object(  " +
+	"'polygonId',  gp.id," +
+	"'geofenceId', g.id," +
+	"'title',      g.title," +
+	"'userId',     g.user_id, " +
+	"'category',   g.category," +
+	"'tags',       g.tags," +
+	"'attributes', (SELECT json_object_agg(a.key, a.value) FROM jsonb_each_text(g.attributes) a)," +
+	"'kind',       CASE WHEN gp.radius IS NOT NULL THEN 'circle' " +
+	"                   WHEN gp.route IS NOT NULL THEN 'corridor' ELSE 'polygon' END," +
+	"'geometryFull',   ST_AsGeoJSON(polygon::geometry)::json," +
+	"'center',     ST_AsGeoJSON(gp.center::geometry)::json," +
+	"'radius',     gp.radius," +
+	"'route',      ST_AsGeoJSON(gp.route::geometry)::json," +
+	"'width',      gp.width," +
+	"'minAltitude', gp.min_altitude," +
+	"'maxAltitude', gp.max_altitude," +
+	"'schedules',  (SELECT json_agg(json_build_object(" +
+	"                 'daysOfWeek', s.days_of_week," +
+	"                 'startTime',  s.start_time," +
+	"                 'endTime',    s.end_time," +
+	"                 'timeZone',   s.time_zone," +
+	"                 'startDate',  s.start_date," +
+	"                 'endDate',    s.end_date))" +
+	"               FROM geo.gz_schedule s WHERE s.gz_id = g.id)) "
+
 // GeoStorage - структура для работы с postgress.
 type GeoStorage struct {
 	Storage
+	// simplifyTolerance - допуск упрощения полигонов геозон в метрах.
+	simplifyTolerance float64
 }
 
 // NewGeoStorage - Конструктор.
@@ -26,6 +63,7 @@ func NewGeoStorage(cfg *config.Config) *GeoStorage {
 			db:  nil,
 			log: cfg.Log,
 		},
+		cfg.SimplifyTolerance,
 	}
 }
 
@@ -72,14 +110,7 @@ func (s *GeoStorage) GetAllGeozones() ([]models.Geofence, error) {
 // GetFullGeometry - загружаем всю информацию об геозонах.
 func (s *GeoStorage) GetFullGeometry() (map[uint64]*models.GeofenceExt, error) {
 	rows, err := s.db.Query(context.Background(),
-		"SELECT json_build_object(  "+
-			"'polygonId',  gp.id,"+
-			"'geofenceId', g.id,"+
-			"'title',      g.title,"+
-			"'userId',     g.user_id, "+
-			"'geometryFull',   ST_AsGeoJSON(polygon::geometry)::json,"+
-			"'geometrySimplify', ST_Simplify(polygon::geometry,0.1,true)::json,"+
-			"'geometryBoundingBox',  ST_AsBinary(ST_Extent(polygon::geometry))) "+
+		selectGeofenceExt+
 			"FROM geo.gz_polygon gp inner join geo.geozone g on gp.gz_id = g.id group by gp.id,g.id;")
 
 	if err != nil && errors.Is(err, pgx.ErrNoRows) {
@@ -99,36 +130,42 @@ func (s *GeoStorage) parseData(rows pgx.Rows) map[uint64]*models.GeofenceExt {
 	var geofence = make(map[uint64]*models.GeofenceExt)
 
 	for rows.Next() {
-		var g models.GeofenceExt
+		var data []byte
 
-		err := rows.Scan(&g)
+		err := rows.Scan(&data)
 		if err != nil {
 			logger.LogError(err, s.log)
 
 			continue
 		}
 
-		var p orb.Bound
+		var g models.GeofenceExt
 
-		if err = wkb.Scanner(&p).Scan([]byte(g.GeometryBoundingBox)); err != nil {
-			logger.LogError(err, s.log)
+		// строку, которую не удалось разобрать, помещаем в карантин, если в ней удалось найти id полигона
+		if err = json.Unmarshal(data, &g); err != nil {
+			err = errors.Wrap(err, "invalid row")
 
-			continue
-		}
+			var ids models.Geofence
+			if json.Unmarshal(data, &ids) != nil || ids.PolygonID == 0 {
+				logger.LogError(errors.Wrap(err, "[GEO_STORAGE]::parseData"), s.log)
 
-		if g.BoundingBox, err = rtreego.NewRectFromPoints(
-			rtreego.Point{p.Min.X(), p.Min.Y()},
-			rtreego.Point{p.Max.X(), p.Max.Y()}); err != nil {
-			logger.LogError(err, s.log)
+				continue
+			}
 
-			continue
+			g = models.GeofenceExt{PolygonID: ids.PolygonID, GeofenceID: ids.GeofenceID, UserID: ids.UserID, Title: ids.Title}
+		} else {
+			err = prepare(&g, s.simplifyTolerance)
+		}
+
+		if err != nil {
+			g.QuarantineReason = err.Error()
+			logger.LogError(errors.Wrapf(err, "[GEO_STORAGE]::parseData : polygon %d quarantined", g.PolygonID), s.log)
 		}
 
-		gs, ok := g.GeometrySimplify.Coordinates.(orb.Polygon)
-		// функция ST_Simplify в PostGis может обрезать полигон,
-		// для такого случая будем использовать полный полигон геозоны
-		if ok && (len(gs) > 0 && len(gs[0]) <= 4) {
-			g.GeometrySimplify = *g.GeometryFull
+		for _, issue := range g.Issues {
+			if issue.Repaired {
+				logger.LogDebug(fmt.Sprintf("[GEO_STORAGE]::parseData : polygon %d %s", g.PolygonID, issue), s.log)
+			}
 		}
 
 		g.GeometryFull = nil
@@ -144,14 +181,7 @@ func (s *GeoStorage) parseData(rows pgx.Rows) map[uint64]*models.GeofenceExt {
 
 func (s *GeoStorage) GetNewRecords(ids []uint64) (map[uint64]*models.GeofenceExt, error) {
 	rows, err := s.db.Query(context.Background(),
-		"SELECT json_build_object(  "+
-			"'polygonId',  gp.id,"+
-			"'geofenceId', g.id,"+
-			"'title',      g.title,"+
-			"'userId',     g.user_id, "+
-			"'geometryFull',   ST_AsGeoJSON(polygon::geometry)::json,"+
-			"'geometrySimplify', ST_Simplify(polygon::geometry,0.1,true)::json,"+
-			"'geometryBoundingBox',  ST_AsBinary(ST_Extent(polygon::geometry))) "+
+		selectGeofenceExt+
 			"FROM geo.gz_polygon gp "+
 			"INNER JOIN  geo.geozone g ON gp.gz_id = g.id "+
 			"GROUP BY gp.id,g.id HAVING NOT (gp.id =ANY($1));", ids)
@@ -168,3 +198,91 @@ func (s *GeoStorage) GetNewRecords(ids []uint64) (map[uint64]*models.GeofenceExt
 
 	return s.parseData(rows), nil
 }
+
+// prepare - проверка и подготовка геометрии геозоны к поиску, полигоны упрощаются с допуском tolerance метров.
+// Найденные дефекты геометрии сохраняются в g.Issues, если их не удалось исправить, то возвращается ошибка.
+func prepare(g *models.GeofenceExt, tolerance float64) error {
+	if g.MinAltitude != nil && g.MaxAltitude != nil && *g.MinAltitude > *g.MaxAltitude {
+		return errors.New("invalid altitude band")
+	}
+
+	for i := range g.Schedules {
+		if err := g.Schedules[i].Compile(); err != nil {
+			return err
+		}
+	}
+
+	switch g.Kind {
+	case models.KindCircle:
+		if g.Center == nil {
+			return errors.New("circle without center")
+		}
+
+		center, ok := g.Center.Geometry().(orb.Point)
+		if !ok || !geometry.ValidCoordinates(center) || g.Radius <= 0 {
+			return errors.New("invalid circle center or radius")
+		}
+
+		g.Shape = geometry.Circle{Center: center, Radius: g.Radius}
+	case models.KindCorridor:
+		if g.Route == nil {
+			return errors.New("corridor without route")
+		}
+
+		route, ok := g.Route.Geometry().(orb.LineString)
+		if !ok || g.Width <= 0 {
+			return errors.New("invalid corridor route or width")
+		}
+
+		if route, g.Issues, ok = geometry.RepairRoute(route); !ok {
+			return errors.New("invalid corridor route")
+		}
+
+		g.Shape = geometry.NewCorridor(route, g.Width)
+	case models.KindPolygon:
+		if g.GeometryFull == nil {
+			return errors.New("polygon without geometry")
+		}
+
+		full, issues, valid := geometry.Repair(g.GeometryFull.Geometry())
+		if g.Issues = issues; !valid {
+			return errors.Errorf("invalid %s geometry", g.GeometryFull.Type)
+		}
+
+		simplified, band, changed := geometry.Simplify(full, tolerance)
+
+		shape, ok := geometry.NewPolygons(simplified)
+		if !ok {
+			return errors.Errorf("unsupported geometry type %s", g.GeometryFull.Type)
+		}
+
+		// полную геометрию храним, только если упрощение отбросило часть вершин:
+		// она нужна для точной проверки точек вблизи границы
+		if changed {
+			shape.SetFull(full, band)
+		}
+
+		g.GeometrySimplify = *geojson.NewGeometry(simplified)
+		g.Shape = shape
+	default:
+		return errors.Errorf("unknown geofence kind %q", g.Kind)
+	}
+
+	bounds := g.Shape.Bounds()
+	if len(bounds) == 0 {
+		return errors.New("empty geometry")
+	}
+
+	bound := bounds[0]
+	for i := 1; i < len(bounds); i++ {
+		bound = bound.Union(bounds[i])
+	}
+
+	var err error
+
+	g.BoundingBox, err = rtreego.NewRectFromPoints(
+		rtreego.Point{bound.Min.X(), bound.Min.Y()},
+		rtreego.Point{bound.Max.X(), bound.Max.Y()})
+
+	return err
+}
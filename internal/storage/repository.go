@@ -1,12 +1,18 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/paulmach/orb"
+	"github.com/pkg/errors"
 
 	"github.com/X-Keeper/geoborder/internal/config"
 	"github.com/X-Keeper/geoborder/internal/storage/models"
 )
 
+// ErrNotFound - запрошенные геозоны не найдены.
+var ErrNotFound = errors.New("not found")
+
 type Connector interface {
 	Connect(cfg *config.DBConfig) (bool, error)
 	Close() error
@@ -20,6 +26,14 @@ type GeoStorage interface {
 	GetNewRecords(ids []uint64) (map[uint64]*models.GeofenceExt, error)
 }
 
+// EventSink - получатель переходов устройств относительно геозон для внешних систем.
+// Write возвращает nil только после того, как получатель сохранил весь пакет, иначе пакет передается
+// повторно. События передаются хотя бы один раз, поэтому получатель должен учитывать повторы по Sequence.
+type EventSink interface {
+	Write(ctx context.Context, events []models.Event) error
+	Close() error
+}
+
 // DevStorage - интерфейс для работы с БД.
 type DevStorage interface {
 	Connector
@@ -29,7 +43,18 @@ type DevStorage interface {
 type MemoryGeoCache interface {
 	Load() (count int, err error)
 	Update() (count int, err error)
-	FindGeofenceByPoint(point orb.Point, userID *uint64, withDistance bool) ([]models.Geofence, error)
-	CheckGeofenceByPoint(point orb.Point, geofenceID []uint64) ([]models.Geofence, error)
-	GetDistanceToGeofence(point orb.Point) ([]models.Geofence, error)
+	FindGeofenceByPoint(point models.Point, userID *uint64, withDistance bool,
+		filter *models.Filter) ([]models.Geofence, error)
+	CheckGeofenceByPoint(point models.Point, geofenceID []uint64, filter *models.Filter) ([]models.Geofence, error)
+	GetDistanceToGeofence(point models.Point, userID *uint64, filter *models.Filter) ([]models.Geofence, error)
+	FindNearbyGeofences(point models.Point, radius float64, userID *uint64,
+		filter *models.Filter) ([]models.Geofence, error)
+	FindNearestGeofences(point models.Point, userID *uint64, limit int, maxDistance float64,
+		filter *models.Filter) ([]models.Geofence, error)
+	FindGeofencesInBounds(bound orb.Bound, userID *uint64, tolerance float64,
+		filter *models.Filter) ([]models.GeofenceGeometry, error)
+	FindTrackCrossings(track []models.Point, userID *uint64, filter *models.Filter) ([]models.Crossing, error)
+	FindGeofencesByPolygon(query orb.Geometry, userID *uint64, filter *models.Filter) ([]models.PolygonRelation, error)
+	GetGeofence(geofenceID, polygonID uint64, filter *models.Filter) ([]models.GeofenceDetails, error)
+	GetQuarantine(userID *uint64) ([]models.QuarantinedGeofence, error)
 }
@@ -0,0 +1,277 @@
+// Package tracking - отслеживание состояния устройств относительно геозон.
+package tracking
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// ErrStalePoint - точка устройства старше последней обработанной, переходы по ней не определяются.
+var ErrStalePoint = errors.New("point is older than the last known device position")
+
+// Publisher - получатель переходов ENTER, EXIT и DWELL, определенных трекером.
+type Publisher interface {
+	Publish(events ...models.Event)
+}
+
+// Publishers - передача переходов нескольким получателям по порядку. Получатель видит номера, которые
+// присвоили событиям предыдущие получатели, поэтому шина событий указывается первой.
+type Publishers []Publisher
+
+func (p Publishers) Publish(events ...models.Event) {
+	for _, publisher := range p {
+		publisher.Publish(events...)
+	}
+}
+
+// Tracker - состояние устройств: геозоны, в которых находилось устройство по последней точке.
+// Переходы ENTER, EXIT и INSIDE определяются сравнением этого состояния с геозонами, в которые
+// попадает очередная точка устройства. DWELL определяется один раз за время нахождения в геозоне,
+// когда устройство находится внутри дольше dwell. Состояние устройства хранится отдельно для каждого
+// пользователя, по геозонам которого отслеживается устройство.
+type Tracker struct {
+	sync.Mutex
+
+	geoCache  storage.MemoryGeoCache
+	publisher Publisher
+	// переходы публикуются вне блокировки состояния в порядке их определения: каждая точка получает
+	// номер очереди публикации под блокировкой состояния и публикует переходы, когда подойдет её очередь
+	publishCond *sync.Cond
+	// номер очереди следующей точки и точки, переходы которой публикуются
+	queued, publishing uint64
+	// состояние устройств
+	devices map[deviceKey]*device
+	// время, после которого состояние устройства без новых точек удаляется
+	ttl time.Duration
+	// время нахождения в геозоне, после которого определяется DWELL, 0 - DWELL не определяется
+	dwell time.Duration
+}
+
+// deviceKey - устройство и пользователь, по геозонам которого оно отслеживается, 0 - геозоны всех пользователей.
+type deviceKey struct {
+	deviceID uint64
+	userID   uint64
+}
+
+// device - последнее известное состояние устройства.
+type device struct {
+	// время последней точки
+	seen time.Time
+	// геозоны, внутри которых находится устройство, key - id геозоны
+	geofences map[uint64]visit
+}
+
+// visit - нахождение устройства в геозоне.
+type visit struct {
+	models.Geofence
+	// время точки, по которой определен вход
+	entered time.Time
+	// DWELL для этого нахождения уже определен
+	dwelled bool
+}
+
+// NewTracker - publisher получает переходы ENTER, EXIT и DWELL, nil - переходы только возвращаются из Track.
+func NewTracker(geoCache storage.MemoryGeoCache, publisher Publisher, ttl, dwell time.Duration) *Tracker {
+	return &Tracker{
+		geoCache:    geoCache,
+		publisher:   publisher,
+		publishCond: sync.NewCond(&sync.Mutex{}),
+		devices:     make(map[deviceKey]*device),
+		ttl:         ttl,
+		dwell:       dwell,
+	}
+}
+
+// Track - обработка очередной точки устройства. Возвращает переходы относительно геозон пользователя userID,
+// nil - геозоны всех пользователей. Геозона, граница которой проходит в пределах точности координат,
+// сохраняет прежнее состояние: устройство не входит в неё и не выходит из неё. Точки, которые старше
+// последней обработанной точки устройства, не меняют состояние, для них возвращается ErrStalePoint.
+func (t *Tracker) Track(deviceID uint64, userID *uint64, point models.Point) ([]models.Event, error) {
+	at := point.At()
+
+	found, err := t.geoCache.FindGeofenceByPoint(point, userID, false, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "find geofences")
+	}
+
+	key := deviceKey{deviceID: deviceID}
+	if userID != nil {
+		key.userID = *userID
+	}
+
+	trackPoint := models.TrackPoint{Point: point.Point, Time: at.UnixNano() / int64(time.Millisecond)}
+
+	t.Lock()
+
+	events, err := t.track(key, trackPoint, at, found)
+	if err != nil {
+		t.Unlock()
+
+		return nil, err
+	}
+
+	turn := t.queued
+	t.queued++
+
+	t.Unlock()
+
+	if t.publisher != nil {
+		t.publish(turn, events)
+	}
+
+	return events, nil
+}
+
+// publish - публикация переходов, кроме INSIDE, когда подойдет очередь turn.
+func (t *Tracker) publish(turn uint64, events []models.Event) {
+	published := make([]models.Event, 0, len(events))
+
+	for i := range events {
+		if events[i].Type != models.EventInside {
+			published = append(published, events[i])
+		}
+	}
+
+	t.publishCond.L.Lock()
+	defer t.publishCond.L.Unlock()
+
+	for t.publishing != turn {
+		t.publishCond.Wait()
+	}
+
+	t.publisher.Publish(published...)
+
+	t.publishing++
+	t.publishCond.Broadcast()
+}
+
+// track - переходы устройства по геозонам found, в которые попадает точка point со временем at.
+func (t *Tracker) track(
+	key deviceKey, point models.TrackPoint, at time.Time, found []models.Geofence,
+) ([]models.Event, error) {
+	deviceID := key.deviceID
+
+	state, ok := t.devices[key]
+	if !ok {
+		state = &device{geofences: make(map[uint64]visit)}
+		t.devices[key] = state
+	} else if at.Before(state.seen) {
+		return nil, ErrStalePoint
+	}
+
+	current := make(map[uint64]visit, len(found))
+	uncertain := make(map[uint64]models.Geofence)
+
+	for i := range found {
+		if found[i].Containment == models.ContainmentBoundaryUncertain {
+			uncertain[found[i].GeofenceID] = found[i]
+
+			continue
+		}
+
+		current[found[i].GeofenceID] = visit{Geofence: found[i], entered: at}
+	}
+
+	// при неопределенном положении устройство остается в геозоне, в которой было
+	for id, gz := range uncertain {
+		if _, inside := state.geofences[id]; inside {
+			if _, ok = current[id]; !ok {
+				current[id] = visit{Geofence: gz, entered: at}
+			}
+		}
+	}
+
+	events := make([]models.Event, 0, len(current)+len(state.geofences))
+
+	for id, previous := range state.geofences {
+		if _, inside := current[id]; !inside {
+			events = append(events, event(deviceID, previous.Geofence, models.EventExit, point))
+		}
+	}
+
+	for id, gz := range current {
+		previous, inside := state.geofences[id]
+		if !inside {
+			events = append(events, event(deviceID, gz.Geofence, models.EventEnter, point))
+
+			continue
+		}
+
+		gz.entered, gz.dwelled = previous.entered, previous.dwelled
+		events = append(events, event(deviceID, gz.Geofence, models.EventInside, point))
+
+		if t.dwell > 0 && !gz.dwelled && at.Sub(gz.entered) >= t.dwell {
+			gz.dwelled = true
+			events = append(events, event(deviceID, gz.Geofence, models.EventDwell, point))
+		}
+
+		current[id] = gz
+	}
+
+	state.seen = at
+	state.geofences = current
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Type != events[j].Type {
+			return order(events[i].Type) < order(events[j].Type)
+		}
+
+		return events[i].GeofenceID < events[j].GeofenceID
+	})
+
+	return events, nil
+}
+
+// Expire - удаляет состояние устройств, от которых не было точек дольше ttl. Возвращает количество
+// удаленных устройств.
+func (t *Tracker) Expire(now time.Time) int {
+	if t.ttl <= 0 {
+		return 0
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	count := 0
+
+	for id, state := range t.devices {
+		if now.Sub(state.seen) > t.ttl {
+			delete(t.devices, id)
+			count++
+		}
+	}
+
+	return count
+}
+
+func event(deviceID uint64, gz models.Geofence, eventType models.EventType, point models.TrackPoint) models.Event {
+	return models.Event{
+		DeviceID:   deviceID,
+		GeofenceID: gz.GeofenceID,
+		PolygonID:  gz.PolygonID,
+		UserID:     gz.UserID,
+		Title:      gz.Title,
+		Type:       eventType,
+		Point:      point,
+	}
+}
+
+// order - порядок переходов в ответе: сначала выходы, затем входы, затем нахождение внутри и DWELL.
+func order(eventType models.EventType) int {
+	switch eventType {
+	case models.EventExit:
+		return 0
+	case models.EventEnter:
+		return 1
+	case models.EventInside:
+		return 2 // nolint:gomnd // после входов
+	default:
+		return 3 // nolint:gomnd // последними
+	}
+}
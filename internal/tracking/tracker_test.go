@@ -0,0 +1,207 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/storage"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// zonesCache - кэш из двух геозон-полос по долготе: геозона 1 - [0, 1), геозона 2 - [0.5, 2).
+// Точка на расстоянии accuracy от границы геозоны 1 попадает в неё с неопределенным положением.
+type zonesCache struct {
+	storage.MemoryGeoCache
+}
+
+func (zonesCache) FindGeofenceByPoint(point models.Point, _ *uint64, _ bool,
+	_ *models.Filter) ([]models.Geofence, error) {
+	var res []models.Geofence
+
+	x := point.X()
+
+	switch {
+	case point.Accuracy > 0 && x < 1+point.Accuracy && x > 1-point.Accuracy:
+		res = append(res, models.Geofence{GeofenceID: 1, PolygonID: 10, Containment: models.ContainmentBoundaryUncertain})
+	case x >= 0 && x < 1:
+		res = append(res, models.Geofence{GeofenceID: 1, PolygonID: 10, Containment: models.ContainmentInside})
+	}
+
+	if x >= 0.5 && x < 2 {
+		res = append(res, models.Geofence{GeofenceID: 2, PolygonID: 20, Containment: models.ContainmentInside})
+	}
+
+	return res, nil
+}
+
+type step struct {
+	x        float64
+	accuracy float64
+	time     int64
+	want     []models.EventType // переходы по геозонам 1 и 2, пусто - нет перехода
+}
+
+func TestTrackerTrack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "enter, stay and exit",
+			steps: []step{
+				{x: -1, time: 1000, want: []models.EventType{"", ""}},
+				{x: 0.2, time: 2000, want: []models.EventType{models.EventEnter, ""}},
+				{x: 0.7, time: 3000, want: []models.EventType{models.EventInside, models.EventEnter}},
+				{x: 1.5, time: 4000, want: []models.EventType{models.EventExit, models.EventInside}},
+				{x: 3, time: 5000, want: []models.EventType{"", models.EventExit}},
+			},
+		},
+		{
+			name: "uncertain position keeps state",
+			steps: []step{
+				{x: 0.2, time: 1000, want: []models.EventType{models.EventEnter, ""}},
+				{x: 1.05, accuracy: 0.1, time: 2000, want: []models.EventType{models.EventInside, models.EventEnter}},
+				{x: 2.5, time: 3000, want: []models.EventType{models.EventExit, models.EventExit}},
+				{x: 0.95, accuracy: 0.1, time: 4000, want: []models.EventType{"", models.EventEnter}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracker := NewTracker(zonesCache{}, nil, time.Hour, 0)
+
+			for i, s := range tt.steps {
+				point := models.Point{Point: orb.Point{s.x, 0}, Accuracy: s.accuracy, Time: s.time}
+
+				events, err := tracker.Track(7, nil, point)
+				if err != nil {
+					t.Fatalf("step %d: Track() error = %v", i, err)
+				}
+
+				got := []models.EventType{"", ""}
+				for _, e := range events {
+					if e.DeviceID != 7 || e.Point.Time != s.time {
+						t.Errorf("step %d: event %+v has wrong device or time", i, e)
+					}
+
+					got[e.GeofenceID-1] = e.Type
+				}
+
+				if got[0] != s.want[0] || got[1] != s.want[1] {
+					t.Errorf("step %d: Track() = %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackerStaleAndExpire(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTracker(zonesCache{}, nil, time.Minute, 0)
+
+	if _, err := tracker.Track(1, nil, models.Point{Point: orb.Point{0.2, 0}, Time: 60000}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	if _, err := tracker.Track(1, nil, models.Point{Point: orb.Point{3, 0}, Time: 30000}); !errors.Is(err, ErrStalePoint) {
+		t.Errorf("Track() error = %v, want ErrStalePoint", err)
+	}
+
+	if count := tracker.Expire(time.Unix(90, 0)); count != 0 {
+		t.Errorf("Expire() = %d, want 0", count)
+	}
+
+	if count := tracker.Expire(time.Unix(121, 0)); count != 1 {
+		t.Errorf("Expire() = %d, want 1", count)
+	}
+
+	// после удаления состояния устройство снова входит в геозону
+	events, err := tracker.Track(1, nil, models.Point{Point: orb.Point{0.2, 0}, Time: 130000})
+	if err != nil || len(events) != 1 || events[0].Type != models.EventEnter {
+		t.Errorf("Track() = %v, %v, want one enter event", events, err)
+	}
+}
+
+func TestTrackerStatePerUser(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTracker(zonesCache{}, nil, time.Hour, 0)
+	user := uint64(5)
+
+	tests := []struct {
+		userID *uint64
+		x      float64
+		time   int64
+		want   models.EventType
+	}{
+		{userID: &user, x: 0.2, time: 1000, want: models.EventEnter},
+		// состояние по геозонам всех пользователей не зависит от состояния по геозонам пользователя
+		{userID: nil, x: 0.2, time: 2000, want: models.EventEnter},
+		{userID: &user, x: 0.3, time: 3000, want: models.EventInside},
+		{userID: nil, x: 3, time: 4000, want: models.EventExit},
+		{userID: &user, x: 0.3, time: 5000, want: models.EventInside},
+	}
+
+	for i, tt := range tests {
+		events, err := tracker.Track(1, tt.userID, models.Point{Point: orb.Point{tt.x, 0}, Time: tt.time})
+		if err != nil || len(events) != 1 || events[0].Type != tt.want {
+			t.Errorf("step %d: Track() = %v, %v, want %s", i, events, err, tt.want)
+		}
+	}
+}
+
+// recorder - получатель опубликованных переходов.
+type recorder struct {
+	events []models.Event
+}
+
+func (r *recorder) Publish(events ...models.Event) {
+	r.events = append(r.events, events...)
+}
+
+func TestTrackerDwellAndPublish(t *testing.T) {
+	t.Parallel()
+
+	published := &recorder{}
+	tracker := NewTracker(zonesCache{}, published, time.Hour, 2*time.Second)
+
+	for _, ms := range []int64{1000, 2000, 3000, 4000} {
+		if _, err := tracker.Track(1, nil, models.Point{Point: orb.Point{0.2, 0}, Time: ms}); err != nil {
+			t.Fatalf("Track() error = %v", err)
+		}
+	}
+
+	if _, err := tracker.Track(1, nil, models.Point{Point: orb.Point{3, 0}, Time: 5000}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	// INSIDE не публикуется, DWELL - один раз за время нахождения в геозоне
+	want := []struct {
+		eventType models.EventType
+		time      int64
+	}{
+		{models.EventEnter, 1000},
+		{models.EventDwell, 3000},
+		{models.EventExit, 5000},
+	}
+
+	if len(published.events) != len(want) {
+		t.Fatalf("published %v, want %d events", published.events, len(want))
+	}
+
+	for i, w := range want {
+		if e := published.events[i]; e.Type != w.eventType || e.Point.Time != w.time || e.GeofenceID != 1 {
+			t.Errorf("event %d = %+v, want %s at %d", i, e, w.eventType, w.time)
+		}
+	}
+}
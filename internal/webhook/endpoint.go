@@ -0,0 +1,54 @@
+// Package webhook - доставка переходов устройств относительно геозон на HTTP-адреса пользователей.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint - адрес, на который доставляются события геозон пользователя.
+type Endpoint struct {
+	UserID uint64 `json:"user_id"`
+	URL    string `json:"url"`
+	// Secret - ключ подписи HMAC-SHA256 запросов
+	Secret string `json:"secret"`
+}
+
+// LoadEndpoints - чтение адресов из JSON-файла со списком Endpoint.
+func LoadEndpoints(path string) ([]Endpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read webhooks file")
+	}
+
+	var endpoints []Endpoint
+
+	if err = json.Unmarshal(data, &endpoints); err != nil {
+		return nil, errors.Wrap(err, "parse webhooks file")
+	}
+
+	for i := range endpoints {
+		if endpoints[i].URL == "" {
+			return nil, errors.Errorf("webhook %d of user %d has no url", i, endpoints[i].UserID)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// Sign - подпись тела запроса: hex(HMAC-SHA256(secret, timestamp + "." + body)). Получатель вычисляет
+// подпись по заголовку TimestampHeader и телу запроса и сравнивает с заголовком SignatureHeader.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10))) // nolint:errcheck // hash.Hash не возвращает ошибок
+	mac.Write([]byte("."))                              // nolint:errcheck // hash.Hash не возвращает ошибок
+	mac.Write(body)                                     // nolint:errcheck // hash.Hash не возвращает ошибок
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,394 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+)
+
+// Payload - тело запроса webhook.
+type Payload struct {
+	DeliveryID uint64 `json:"delivery_id"`
+	models.EventMessage
+}
+
+// Delivery - событие, ожидающее доставки на адрес пользователя, или недоставленное событие.
+type Delivery struct {
+	ID      uint64  `json:"id"`
+	UserID  uint64  `json:"user_id"`
+	URL     string  `json:"url"`
+	Payload Payload `json:"payload"`
+	// Attempts - количество выполненных попыток доставки
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	// FailedAt - время, когда доставка прекращена, для недоставленных событий
+	FailedAt time.Time `json:"failed_at,omitempty"`
+}
+
+// state - состояние очереди доставки.
+type state struct {
+	NextID uint64
+	// события, ожидающие доставки, key - номер события
+	queue map[uint64]*Delivery
+	// номера событий каждого адреса в порядке постановки в очередь
+	endpoints map[endpointKey][]uint64
+	Dead      []*Delivery
+}
+
+// snapshot - сохраняемое состояние очереди доставки, события очереди упорядочены по номерам.
+type snapshot struct {
+	NextID uint64      `json:"next_id"`
+	Queue  []*Delivery `json:"queue"`
+	Dead   []*Delivery `json:"dead"`
+}
+
+// операции записей журнала очереди
+const (
+	// opSnapshot - снимок состояния очереди, первая запись журнала
+	opSnapshot = "snapshot"
+	// opQueue - событие поставлено в очередь или отложено до следующей попытки
+	opQueue = "queue"
+	// opDone - событие доставлено
+	opDone = "done"
+	// opDead - событие перенесено в список недоставленных
+	opDead = "dead"
+)
+
+// compactRecords - количество изменений, после которого журнал перезаписывается одним снимком.
+const compactRecords = 10000
+
+// record - запись журнала очереди.
+type record struct {
+	Op       string    `json:"op"`
+	State    *snapshot `json:"state,omitempty"`
+	Delivery *Delivery `json:"delivery,omitempty"`
+}
+
+func newState() state {
+	return state{
+		NextID:    1,
+		queue:     make(map[uint64]*Delivery),
+		endpoints: make(map[endpointKey][]uint64),
+	}
+}
+
+// apply - изменение состояния записью журнала.
+func (st *state) apply(r record) {
+	if r.Op == opSnapshot {
+		if r.State != nil {
+			*st = newState()
+			st.NextID, st.Dead = r.State.NextID, r.State.Dead
+
+			for _, d := range r.State.Queue {
+				st.add(d)
+			}
+		}
+
+		return
+	}
+
+	if r.Delivery == nil {
+		return
+	}
+
+	if r.Delivery.ID >= st.NextID {
+		st.NextID = r.Delivery.ID + 1
+	}
+
+	switch r.Op {
+	case opQueue:
+		st.add(r.Delivery)
+	case opDone:
+		st.remove(r.Delivery.ID)
+	case opDead:
+		st.remove(r.Delivery.ID)
+
+		st.Dead = append(st.Dead, r.Delivery)
+		if len(st.Dead) > maxDeadLetters {
+			st.Dead = st.Dead[len(st.Dead)-maxDeadLetters:]
+		}
+	}
+}
+
+// add - постановка события в конец очереди адреса или замена события, уже стоящего в очереди.
+func (st *state) add(d *Delivery) {
+	if _, ok := st.queue[d.ID]; !ok {
+		key := endpointKey{userID: d.UserID, url: d.URL}
+		st.endpoints[key] = append(st.endpoints[key], d.ID)
+	}
+
+	st.queue[d.ID] = d
+}
+
+// remove - удаление события из очереди. Доставляется первое событие адреса, поэтому оно удаляется сразу,
+// остальные ищутся в очереди адреса.
+func (st *state) remove(id uint64) {
+	d, ok := st.queue[id]
+	if !ok {
+		return
+	}
+
+	delete(st.queue, id)
+
+	key := endpointKey{userID: d.UserID, url: d.URL}
+	ids := st.endpoints[key]
+
+	for i := range ids {
+		if ids[i] != id {
+			continue
+		}
+
+		if i == 0 {
+			ids = ids[1:]
+		} else {
+			ids = append(ids[:i], ids[i+1:]...)
+		}
+
+		break
+	}
+
+	if len(ids) == 0 {
+		delete(st.endpoints, key)
+
+		return
+	}
+
+	st.endpoints[key] = ids
+}
+
+// head - первое событие в очереди адреса key, nil - очередь адреса пуста.
+func (st *state) head(key endpointKey) *Delivery {
+	ids := st.endpoints[key]
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return st.queue[ids[0]]
+}
+
+// snapshot - состояние для записи в журнал.
+func (st *state) snapshot() *snapshot {
+	queue := make([]*Delivery, 0, len(st.queue))
+	for _, d := range st.queue {
+		queue = append(queue, d)
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].ID < queue[j].ID
+	})
+
+	return &snapshot{NextID: st.NextID, Queue: queue, Dead: st.Dead}
+}
+
+func newPayload(id uint64, e models.Event) Payload {
+	return Payload{
+		DeliveryID:   id,
+		EventMessage: models.NewEventMessage(e),
+	}
+}
+
+// journal - журнал очереди доставки: снимок состояния, за которым следуют изменения, по одной JSON-записи
+// в строке. Изменения дописываются в конец файла, sync сбрасывает их на диск. После compactRecords
+// изменений журнал перезаписывается одним снимком через временный файл.
+type journal struct {
+	// защищает file: sync выполняется без блокировки очереди
+	sync.Mutex
+
+	path    string
+	file    *os.File
+	records int
+}
+
+// openJournal - чтение состояния очереди из журнала path, отсутствующий файл - пустая очередь.
+// Журнал открывается для записи вызовом compact.
+func openJournal(path string) (*journal, state, error) {
+	st := newState()
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &journal{path: path}, st, nil
+	}
+
+	if err != nil {
+		return nil, st, errors.Wrap(err, "open webhook queue")
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// последняя запись без перевода строки оборвана сбоем во время записи, неполная запись отбрасывается
+			var r record
+			if json.Unmarshal(line, &r) == nil {
+				st.apply(r)
+			}
+
+			return &journal{path: path}, st, nil
+		}
+
+		if err != nil {
+			return nil, st, errors.Wrap(err, "read webhook queue")
+		}
+
+		var r record
+		if err = json.Unmarshal(line, &r); err != nil {
+			return nil, st, errors.Wrap(err, "parse webhook queue")
+		}
+
+		st.apply(r)
+	}
+}
+
+// append - запись изменений в конец журнала без сброса на диск.
+func (j *journal) append(records ...record) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for i := range records {
+		if err := encoder.Encode(records[i]); err != nil {
+			return errors.Wrap(err, "marshal webhook queue record")
+		}
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	if j.file == nil {
+		return errors.New("webhook queue is not open")
+	}
+
+	if _, err := j.file.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, "write webhook queue")
+	}
+
+	j.records += len(records)
+
+	return nil
+}
+
+// sync - сброс записанных изменений на диск.
+func (j *journal) sync() error {
+	j.Lock()
+	file := j.file
+	j.Unlock()
+
+	if file == nil {
+		return nil
+	}
+
+	// файл, закрытый при сжатии журнала, уже сброшен на диск
+	if err := file.Sync(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return errors.Wrap(err, "sync webhook queue")
+	}
+
+	return nil
+}
+
+// compact - перезапись журнала снимком состояния st через временный файл, чтобы при сбое не потерять
+// прежнее состояние.
+func (j *journal) compact(st *state) error {
+	data, err := json.Marshal(record{Op: opSnapshot, State: st.snapshot()})
+	if err != nil {
+		return errors.Wrap(err, "marshal webhook queue")
+	}
+
+	tmp := j.path + ".tmp"
+
+	if err = writeSynced(tmp, append(data, '\n')); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp, j.path); err != nil {
+		return errors.Wrap(err, "replace webhook queue")
+	}
+
+	if err = syncDir(filepath.Dir(j.path)); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600) // nolint:gomnd // только владелец
+	if err != nil {
+		return errors.Wrap(err, "open webhook queue")
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	if j.file != nil {
+		_ = j.file.Close()
+	}
+
+	j.file, j.records = file, 0
+
+	return nil
+}
+
+// needCompact - количество изменений в журнале достигло compactRecords.
+func (j *journal) needCompact() bool {
+	j.Lock()
+	defer j.Unlock()
+
+	return j.records >= compactRecords
+}
+
+func (j *journal) close() error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+
+	err := j.file.Close()
+	j.file = nil
+
+	return errors.Wrap(err, "close webhook queue")
+}
+
+// writeSynced - запись файла со сбросом на диск.
+func writeSynced(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) // nolint:gomnd // только владелец
+	if err != nil {
+		return errors.Wrap(err, "create webhook queue")
+	}
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "write webhook queue")
+	}
+
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "sync webhook queue")
+	}
+
+	return errors.Wrap(file.Close(), "close webhook queue")
+}
+
+// syncDir - сброс на диск каталога, чтобы переименование файла сохранилось при сбое.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "open webhook queue directory")
+	}
+
+	defer dir.Close()
+
+	return errors.Wrap(dir.Sync(), "sync webhook queue directory")
+}
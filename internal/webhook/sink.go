@@ -0,0 +1,410 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/pkg/logger"
+)
+
+// заголовки запроса webhook
+const (
+	SignatureHeader = "X-Geoborder-Signature"
+	TimestampHeader = "X-Geoborder-Timestamp"
+	DeliveryHeader  = "X-Geoborder-Delivery"
+	EventHeader     = "X-Geoborder-Event"
+)
+
+const (
+	// maxRetryDelay - наибольшая задержка между попытками доставки.
+	maxRetryDelay = time.Hour
+	// maxDeadLetters - количество хранимых недоставленных событий, самые старые удаляются.
+	maxDeadLetters = 1000
+	// idleWait - ожидание при пустой очереди, новые события прерывают его.
+	idleWait = time.Minute
+)
+
+// Sink - доставка событий на адреса пользователей. Каждое событие отправляется POST-запросом с JSON-телом
+// Payload и подписью HMAC-SHA256 в заголовке SignatureHeader. При ошибке доставка повторяется с
+// экспоненциально растущей задержкой, после maxAttempts попыток или ответа 4xx (кроме 408 и 429) событие
+// попадает в список недоставленных. События каждого адреса доставляются отдельным обработчиком, поэтому
+// медленный адрес не задерживает доставку на другие, и по одному в порядке постановки в очередь: пока
+// событие повторяется, следующие события адреса ждут. Изменения очереди и списка недоставленных
+// дописываются в журнал и сбрасываются на диск.
+type Sink struct {
+	sync.Mutex
+
+	// адреса, key - id пользователя
+	endpoints map[uint64][]Endpoint
+	// сигналы обработчикам адресов о новых событиях в очереди
+	workers map[endpointKey]chan struct{}
+	client  *http.Client
+	// журнал очереди, nil - очередь не сохраняется
+	journal     *journal
+	maxAttempts int
+	retryDelay  time.Duration
+	state       state
+	now         func() time.Time
+	log         *logger.Logger
+}
+
+// endpointKey - адрес пользователя.
+type endpointKey struct {
+	userID uint64
+	url    string
+}
+
+// NewSink - восстанавливает очередь доставки из журнала cfg.WebhookQueueFile. События адресов, которые
+// больше не заданы, переносятся в список недоставленных.
+func NewSink(endpoints []Endpoint, cfg *config.Config) (*Sink, error) {
+	s := &Sink{
+		endpoints:   make(map[uint64][]Endpoint),
+		workers:     make(map[endpointKey]chan struct{}),
+		client:      &http.Client{Timeout: cfg.WebhookTimeout},
+		maxAttempts: cfg.WebhookMaxAttempts,
+		retryDelay:  cfg.WebhookRetryDelay,
+		state:       newState(),
+		now:         time.Now,
+		log:         cfg.Log,
+	}
+
+	for _, e := range endpoints {
+		s.endpoints[e.UserID] = append(s.endpoints[e.UserID], e)
+		s.workers[endpointKey{userID: e.UserID, url: e.URL}] = make(chan struct{}, 1)
+	}
+
+	if cfg.WebhookQueueFile != "" {
+		var err error
+
+		if s.journal, s.state, err = openJournal(cfg.WebhookQueueFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var removed []uint64
+
+	for key, ids := range s.state.endpoints {
+		if _, ok := s.workers[key]; !ok {
+			removed = append(removed, ids...)
+		}
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i] < removed[j]
+	})
+
+	for _, id := range removed {
+		d := s.state.queue[id]
+		d.LastError = "webhook is not configured"
+		d.FailedAt = s.now()
+		s.state.apply(record{Op: opDead, Delivery: d})
+	}
+
+	if s.journal != nil {
+		if err := s.journal.compact(&s.state); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Publish - постановка в очередь событий, для пользователей которых заданы адреса. Возвращает управление
+// после сброса журнала на диск, поэтому события, переданные трекером, не теряются при остановке сервиса.
+func (s *Sink) Publish(events ...models.Event) {
+	s.Lock()
+
+	now := s.now()
+	records := make([]record, 0, len(events))
+	woken := make([]endpointKey, 0, len(events))
+
+	for i := range events {
+		for _, endpoint := range s.endpoints[events[i].UserID] {
+			d := &Delivery{
+				ID:          s.state.NextID,
+				UserID:      endpoint.UserID,
+				URL:         endpoint.URL,
+				Payload:     newPayload(s.state.NextID, events[i]),
+				NextAttempt: now,
+			}
+
+			s.state.apply(record{Op: opQueue, Delivery: d})
+			records = append(records, record{Op: opQueue, Delivery: d})
+			woken = append(woken, endpointKey{userID: endpoint.UserID, url: endpoint.URL})
+		}
+	}
+
+	s.persist(records...)
+	s.Unlock()
+
+	if len(records) == 0 {
+		return
+	}
+
+	s.sync()
+
+	for _, key := range woken {
+		select {
+		case s.workers[key] <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run - доставка событий очереди до завершения ctx. Возвращает управление после остановки обработчиков адресов.
+func (s *Sink) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for key, wake := range s.workers {
+		wg.Add(1)
+
+		go func(key endpointKey, wake <-chan struct{}) {
+			defer wg.Done()
+
+			s.work(ctx, key, wake)
+		}(key, wake)
+	}
+
+	wg.Wait()
+
+	if s.journal != nil {
+		if err := s.journal.close(); err != nil {
+			logger.LogError(errors.Wrap(err, "[WEBHOOK]::Run"), s.log)
+		}
+	}
+}
+
+// work - доставка событий адреса key до завершения ctx, wake - сигнал о новых событиях адреса.
+func (s *Sink) work(ctx context.Context, key endpointKey, wake <-chan struct{}) {
+	for {
+		d, wait := s.due(key)
+		if d != nil {
+			s.attempt(ctx, d)
+
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-wake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// DeadLetters - недоставленные события пользователя userID, nil - всех пользователей, от старых к новым.
+func (s *Sink) DeadLetters(userID *uint64) []Delivery {
+	s.Lock()
+	defer s.Unlock()
+
+	res := make([]Delivery, 0, len(s.state.Dead))
+
+	for _, d := range s.state.Dead {
+		if userID == nil || d.UserID == *userID {
+			res = append(res, *d)
+		}
+	}
+
+	return res
+}
+
+// Pending - количество событий, ожидающих доставки.
+func (s *Sink) Pending() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.state.queue)
+}
+
+// due - копия первого события в очереди адреса key, если время его попытки наступило, иначе - время
+// до попытки.
+func (s *Sink) due(key endpointKey) (*Delivery, time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	next := s.state.head(key)
+	if next == nil {
+		return nil, idleWait
+	}
+
+	if wait := next.NextAttempt.Sub(s.now()); wait > 0 {
+		return nil, wait
+	}
+
+	d := *next
+
+	return &d, 0
+}
+
+// attempt - попытка доставки события d и перенос его по результату: удаление из очереди, новая попытка
+// или список недоставленных.
+func (s *Sink) attempt(ctx context.Context, d *Delivery) {
+	retry, err := s.send(ctx, d)
+
+	s.Lock()
+
+	queued, ok := s.state.queue[d.ID]
+	if !ok {
+		s.Unlock()
+
+		return
+	}
+	queued.Attempts++
+
+	op := opQueue
+
+	switch {
+	case err == nil:
+		op = opDone
+	case !retry || queued.Attempts >= s.maxAttempts:
+		logger.LogError(errors.Wrapf(err, "[WEBHOOK]::attempt : delivery %d to %s failed", d.ID, d.URL), s.log)
+
+		op = opDead
+		queued.LastError = err.Error()
+		queued.FailedAt = s.now()
+	default:
+		queued.LastError = err.Error()
+		queued.NextAttempt = s.now().Add(s.backoff(queued.Attempts))
+	}
+
+	s.state.apply(record{Op: op, Delivery: queued})
+
+	// доставленное событие записывается в журнал только номером
+	if op == opDone {
+		queued = &Delivery{ID: queued.ID}
+	}
+
+	s.persist(record{Op: op, Delivery: queued})
+	s.Unlock()
+
+	s.sync()
+}
+
+// send - отправка события. retry - ошибку можно исправить повторной попыткой.
+func (s *Sink) send(ctx context.Context, d *Delivery) (retry bool, err error) {
+	secret, ok := s.secret(d.UserID, d.URL)
+	if !ok {
+		return false, errors.New("webhook is not configured")
+	}
+
+	body, err := json.Marshal(d.Payload)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal payload")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "create request")
+	}
+
+	timestamp := s.now().Unix()
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, body))
+	request.Header.Set(DeliveryHeader, strconv.FormatUint(d.ID, 10))
+	request.Header.Set(EventHeader, d.Payload.Type)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return true, errors.Wrap(err, "send request")
+	}
+
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	_ = response.Body.Close()
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	err = fmt.Errorf("unexpected status %d", response.StatusCode)
+
+	switch {
+	case response.StatusCode == http.StatusRequestTimeout, response.StatusCode == http.StatusTooManyRequests:
+		return true, err
+	case response.StatusCode >= http.StatusBadRequest && response.StatusCode < http.StatusInternalServerError:
+		return false, err
+	}
+
+	return true, err
+}
+
+func (s *Sink) secret(userID uint64, url string) (string, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, e := range s.endpoints[userID] {
+		if e.URL == url {
+			return e.Secret, true
+		}
+	}
+
+	return "", false
+}
+
+// backoff - задержка перед попыткой после attempts неудачных: retryDelay, 2*retryDelay, 4*retryDelay...
+func (s *Sink) backoff(attempts int) time.Duration {
+	delay := s.retryDelay
+
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	return delay
+}
+
+// persist - запись изменений очереди в журнал без сброса на диск, вызывается под блокировкой. Журнал,
+// в котором накопилось compactRecords изменений, перезаписывается снимком очереди.
+func (s *Sink) persist(records ...record) {
+	if s.journal == nil || len(records) == 0 {
+		return
+	}
+
+	if err := s.journal.append(records...); err != nil {
+		logger.LogError(errors.Wrap(err, "[WEBHOOK]::persist"), s.log)
+	}
+
+	if !s.journal.needCompact() {
+		return
+	}
+
+	if err := s.journal.compact(&s.state); err != nil {
+		logger.LogError(errors.Wrap(err, "[WEBHOOK]::persist : compact"), s.log)
+	}
+}
+
+// sync - сброс записанных изменений журнала на диск, вызывается без блокировки, чтобы доставка
+// на другие адреса не ждала записи на диск.
+func (s *Sink) sync() {
+	if s.journal == nil {
+		return
+	}
+
+	if err := s.journal.sync(); err != nil {
+		logger.LogError(errors.Wrap(err, "[WEBHOOK]::sync"), s.log)
+	}
+}
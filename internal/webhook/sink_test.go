@@ -0,0 +1,357 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/paulmach/orb"
+
+	"github.com/X-Keeper/geoborder/internal/config"
+	"github.com/X-Keeper/geoborder/internal/events"
+	"github.com/X-Keeper/geoborder/internal/storage/models"
+	"github.com/X-Keeper/geoborder/internal/tracking"
+)
+
+const secret = "secret"
+
+// receiver - webhook, который отвечает статусами statuses по очереди, затем 200, и проверяет подпись.
+type receiver struct {
+	sync.Mutex
+	t        *testing.T
+	statuses []int
+	payloads []Payload
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		r.t.Errorf("read body: %v", err)
+	}
+
+	timestamp, _ := strconv.ParseInt(request.Header.Get(TimestampHeader), 10, 64)
+	if got, want := request.Header.Get(SignatureHeader), "sha256="+Sign(secret, timestamp, body); got != want {
+		r.t.Errorf("signature = %q, want %q", got, want)
+	}
+
+	var p Payload
+	if err = json.Unmarshal(body, &p); err != nil {
+		r.t.Errorf("unmarshal payload: %v", err)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	if status == http.StatusOK {
+		r.payloads = append(r.payloads, p)
+	}
+
+	w.WriteHeader(status)
+}
+
+func (r *receiver) received() []Payload {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]Payload(nil), r.payloads...)
+}
+
+func newSink(t *testing.T, url, queue string) *Sink {
+	t.Helper()
+
+	sink, err := NewSink([]Endpoint{{UserID: 5, URL: url, Secret: secret}}, &config.Config{
+		WebhookQueueFile:   queue,
+		WebhookMaxAttempts: 3,
+		WebhookRetryDelay:  10 * time.Millisecond,
+		WebhookTimeout:     time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewSink() error = %v", err)
+	}
+
+	return sink
+}
+
+func enter(userID uint64) models.Event {
+	return models.Event{
+		Sequence:   1,
+		DeviceID:   7,
+		UserID:     userID,
+		GeofenceID: 3,
+		Type:       models.EventEnter,
+		Point:      models.TrackPoint{Point: orb.Point{39.7, 47.2}, Time: 1000},
+	}
+}
+
+// wait - ожидание, пока очередь доставки не опустеет.
+func wait(t *testing.T, sink *Sink) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for sink.Pending() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue is not empty: %d", sink.Pending())
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSinkDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		statuses  []int
+		delivered int
+		dead      int
+		attempts  int
+	}{
+		{
+			name:      "delivered after retries",
+			statuses:  []int{http.StatusInternalServerError, http.StatusTooManyRequests},
+			delivered: 1,
+		},
+		{
+			name:     "attempts exhausted",
+			statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			dead:     1,
+			attempts: 3,
+		},
+		{
+			name:     "client error is not retried",
+			statuses: []int{http.StatusBadRequest},
+			dead:     1,
+			attempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &receiver{t: t, statuses: tt.statuses}
+			server := httptest.NewServer(r)
+			defer server.Close()
+
+			sink := newSink(t, server.URL, "")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			go sink.Run(ctx)
+
+			// события пользователей без webhook не ставятся в очередь
+			sink.Publish(enter(5), enter(6))
+			wait(t, sink)
+
+			received := r.received()
+			if len(received) != tt.delivered {
+				t.Fatalf("delivered %v, want %d", received, tt.delivered)
+			}
+
+			if tt.delivered > 0 && (received[0].DeviceID != 7 || received[0].Type != "enter" || received[0].Latitude != 47.2) {
+				t.Errorf("payload = %+v", received[0])
+			}
+
+			dead := sink.DeadLetters(nil)
+			if len(dead) != tt.dead {
+				t.Fatalf("DeadLetters() = %v, want %d", dead, tt.dead)
+			}
+
+			if tt.dead > 0 && (dead[0].Attempts != tt.attempts || dead[0].LastError == "") {
+				t.Errorf("dead letter = %+v, want %d attempts and error", dead[0], tt.attempts)
+			}
+		})
+	}
+}
+
+func TestSinkPersistentQueue(t *testing.T) {
+	t.Parallel()
+
+	r := &receiver{t: t}
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	queue := filepath.Join(t.TempDir(), "queue.json")
+
+	// события, поставленные в очередь до перезапуска, доставляются после него
+	newSink(t, server.URL, queue).Publish(enter(5), enter(5))
+
+	sink := newSink(t, server.URL, queue)
+	if sink.Pending() != 2 {
+		t.Fatalf("Pending() = %d, want 2", sink.Pending())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go sink.Run(ctx)
+	wait(t, sink)
+
+	received := r.received()
+	if len(received) != 2 || received[0].DeliveryID != 1 || received[1].DeliveryID != 2 {
+		t.Errorf("delivered %+v, want deliveries 1 and 2", received)
+	}
+
+	if restored := newSink(t, server.URL, queue); restored.Pending() != 0 {
+		t.Errorf("Pending() after delivery = %d, want 0", restored.Pending())
+	}
+}
+
+func TestSinkRestart(t *testing.T) {
+	t.Parallel()
+
+	r := &receiver{t: t}
+	server := httptest.NewServer(r)
+
+	defer server.Close()
+
+	queue := filepath.Join(t.TempDir(), "queue.json")
+
+	// сервис остановлен до доставки, шина помнит только последнее событие: события поставлены в очередь
+	// при публикации и доставляются после перезапуска
+	publishers := tracking.Publishers{events.NewBus(1), newSink(t, server.URL, queue)}
+	for i := 0; i < 5; i++ {
+		publishers.Publish(enter(5))
+	}
+
+	sink := newSink(t, server.URL, queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go sink.Run(ctx)
+	wait(t, sink)
+
+	received := r.received()
+	if len(received) != 5 {
+		t.Fatalf("delivered %+v, want 5 events", received)
+	}
+
+	for i := range received {
+		if received[i].Sequence != uint64(i+1) {
+			t.Errorf("delivered sequence %d, want %d", received[i].Sequence, i+1)
+		}
+	}
+}
+
+func TestSinkOrder(t *testing.T) {
+	t.Parallel()
+
+	r := &receiver{t: t, statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(r)
+
+	defer server.Close()
+
+	sink := newSink(t, server.URL, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go sink.Run(ctx)
+
+	// пока первое событие повторяется, второе не доставляется
+	enterEvent, exitEvent := enter(5), enter(5)
+	exitEvent.Type = models.EventExit
+
+	sink.Publish(enterEvent, exitEvent)
+	wait(t, sink)
+
+	received := r.received()
+	if len(received) != 2 || received[0].Type != "enter" || received[1].Type != "exit" {
+		t.Errorf("delivered %+v, want enter and exit", received)
+	}
+}
+
+func TestSinkSlowEndpoint(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer slow.Close()
+	defer close(release)
+
+	r := &receiver{t: t}
+	fast := httptest.NewServer(r)
+
+	defer fast.Close()
+
+	sink, err := NewSink([]Endpoint{
+		{UserID: 5, URL: slow.URL, Secret: secret},
+		{UserID: 5, URL: fast.URL, Secret: secret},
+	}, &config.Config{WebhookMaxAttempts: 3, WebhookRetryDelay: 10 * time.Millisecond, WebhookTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewSink() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go sink.Run(ctx)
+
+	// пока медленный адрес не ответил, события доставляются на быстрый
+	sink.Publish(enter(5), enter(5))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(r.received()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %d events to fast endpoint, want 2", len(r.received()))
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSinkJournal(t *testing.T) {
+	t.Parallel()
+
+	r := &receiver{t: t}
+	server := httptest.NewServer(r)
+
+	defer server.Close()
+
+	queue := filepath.Join(t.TempDir(), "queue.json")
+
+	newSink(t, server.URL, queue).Publish(enter(5))
+
+	// запись, оборванная сбоем, отбрасывается
+	file, err := os.OpenFile(queue, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+
+	if _, err = file.WriteString(`{"op":"queue","delivery":{"id":`); err != nil {
+		t.Fatalf("write queue: %v", err)
+	}
+
+	_ = file.Close()
+
+	sink := newSink(t, server.URL, queue)
+	if sink.Pending() != 1 {
+		t.Errorf("Pending() = %d, want 1", sink.Pending())
+	}
+
+	// события адресов, которые больше не заданы, переносятся в список недоставленных
+	if moved := newSink(t, "http://example.com/removed", queue); moved.Pending() != 0 || len(moved.DeadLetters(nil)) != 1 {
+		t.Errorf("Pending() = %d, DeadLetters() = %v, want 0 and 1", moved.Pending(), moved.DeadLetters(nil))
+	}
+}
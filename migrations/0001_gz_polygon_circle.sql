@@ -0,0 +1,8 @@
+-- Геозона-круг: центр и радиус в метрах. Для круга полигон не задается.
+ALTER TABLE geo.gz_polygon ALTER COLUMN polygon DROP NOT NULL;
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS center geography(Point, 4326);
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS radius double precision;
+
+ALTER TABLE geo.gz_polygon DROP CONSTRAINT IF EXISTS gz_polygon_circle_check;
+ALTER TABLE geo.gz_polygon ADD CONSTRAINT gz_polygon_circle_check
+    CHECK ((center IS NULL) = (radius IS NULL) AND (radius IS NULL OR radius > 0));
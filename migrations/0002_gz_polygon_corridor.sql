@@ -0,0 +1,7 @@
+-- Геозона-коридор: линия маршрута и ширина коридора в метрах.
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS route geography(LineString, 4326);
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS width double precision;
+
+ALTER TABLE geo.gz_polygon DROP CONSTRAINT IF EXISTS gz_polygon_corridor_check;
+ALTER TABLE geo.gz_polygon ADD CONSTRAINT gz_polygon_corridor_check
+    CHECK ((route IS NULL) = (width IS NULL) AND (width IS NULL OR width > 0));
@@ -0,0 +1,7 @@
+-- Диапазон высот, в котором действует геозона, в метрах. NULL - без ограничения.
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS min_altitude double precision;
+ALTER TABLE geo.gz_polygon ADD COLUMN IF NOT EXISTS max_altitude double precision;
+
+ALTER TABLE geo.gz_polygon DROP CONSTRAINT IF EXISTS gz_polygon_altitude_check;
+ALTER TABLE geo.gz_polygon ADD CONSTRAINT gz_polygon_altitude_check
+    CHECK (min_altitude IS NULL OR max_altitude IS NULL OR min_altitude <= max_altitude);
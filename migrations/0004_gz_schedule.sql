@@ -0,0 +1,18 @@
+-- Расписания, в которые действует геозона. Геозона без расписаний действует всегда, с несколькими
+-- расписаниями - когда действует хотя бы одно. NULL в поле не ограничивает расписание.
+CREATE TABLE IF NOT EXISTS geo.gz_schedule (
+    id           bigserial PRIMARY KEY,
+    gz_id        bigint    NOT NULL REFERENCES geo.geozone (id) ON DELETE CASCADE,
+    -- дни недели, 0 - воскресенье
+    days_of_week smallint[] CHECK (days_of_week <@ ARRAY[0, 1, 2, 3, 4, 5, 6]::smallint[]),
+    -- интервал времени суток в часовом поясе time_zone, конец меньше начала - интервал через полночь
+    start_time   time,
+    end_time     time,
+    -- часовой пояс IANA, NULL - UTC
+    time_zone    text,
+    -- диапазон дат включительно
+    start_date   date,
+    end_date     date
+);
+
+CREATE INDEX IF NOT EXISTS gz_schedule_gz_id_idx ON geo.gz_schedule (gz_id);
@@ -0,0 +1,5 @@
+-- Категория, теги и атрибуты геозоны для отбора геозон в запросах.
+ALTER TABLE geo.geozone ADD COLUMN IF NOT EXISTS category text;
+ALTER TABLE geo.geozone ADD COLUMN IF NOT EXISTS tags text[];
+-- значения атрибутов при загрузке приводятся к строкам
+ALTER TABLE geo.geozone ADD COLUMN IF NOT EXISTS attributes jsonb CHECK (jsonb_typeof(attributes) = 'object');
@@ -24,6 +24,155 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type GeometryFormat int32
+
+const (
+	GeometryFormat_GEOJSON GeometryFormat = 0
+	GeometryFormat_WKB     GeometryFormat = 1
+)
+
+var GeometryFormat_name = map[int32]string{
+	0: "GEOJSON",
+	1: "WKB",
+}
+
+var GeometryFormat_value = map[string]int32{
+	"GEOJSON": 0,
+	"WKB":     1,
+}
+
+func (x GeometryFormat) String() string {
+	return proto.EnumName(GeometryFormat_name, int32(x))
+}
+
+func (GeometryFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{0}
+}
+
+type Relation int32
+
+const (
+	Relation_RELATION_UNSPECIFIED Relation = 0
+	Relation_INTERSECTS           Relation = 1
+	Relation_CONTAINS             Relation = 2
+	Relation_WITHIN               Relation = 3
+)
+
+var Relation_name = map[int32]string{
+	0: "RELATION_UNSPECIFIED",
+	1: "INTERSECTS",
+	2: "CONTAINS",
+	3: "WITHIN",
+}
+
+var Relation_value = map[string]int32{
+	"RELATION_UNSPECIFIED": 0,
+	"INTERSECTS":           1,
+	"CONTAINS":             2,
+	"WITHIN":               3,
+}
+
+func (x Relation) String() string {
+	return proto.EnumName(Relation_name, int32(x))
+}
+
+func (Relation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{1}
+}
+
+type GeofenceKind int32
+
+const (
+	GeofenceKind_POLYGON  GeofenceKind = 0
+	GeofenceKind_CIRCLE   GeofenceKind = 1
+	GeofenceKind_CORRIDOR GeofenceKind = 2
+)
+
+var GeofenceKind_name = map[int32]string{
+	0: "POLYGON",
+	1: "CIRCLE",
+	2: "CORRIDOR",
+}
+
+var GeofenceKind_value = map[string]int32{
+	"POLYGON":  0,
+	"CIRCLE":   1,
+	"CORRIDOR": 2,
+}
+
+func (x GeofenceKind) String() string {
+	return proto.EnumName(GeofenceKind_name, int32(x))
+}
+
+func (GeofenceKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{2}
+}
+
+type Containment int32
+
+const (
+	Containment_CONTAINMENT_UNSPECIFIED Containment = 0
+	Containment_INSIDE                  Containment = 1
+	Containment_OUTSIDE                 Containment = 2
+	Containment_BOUNDARY_UNCERTAIN      Containment = 3
+)
+
+var Containment_name = map[int32]string{
+	0: "CONTAINMENT_UNSPECIFIED",
+	1: "INSIDE",
+	2: "OUTSIDE",
+	3: "BOUNDARY_UNCERTAIN",
+}
+
+var Containment_value = map[string]int32{
+	"CONTAINMENT_UNSPECIFIED": 0,
+	"INSIDE":                  1,
+	"OUTSIDE":                 2,
+	"BOUNDARY_UNCERTAIN":      3,
+}
+
+func (x Containment) String() string {
+	return proto.EnumName(Containment_name, int32(x))
+}
+
+func (Containment) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{3}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_ENTER            EventType = 1
+	EventType_EVENT_EXIT             EventType = 2
+	EventType_EVENT_INSIDE           EventType = 3
+	EventType_EVENT_DWELL            EventType = 4
+)
+
+var EventType_name = map[int32]string{
+	0: "EVENT_TYPE_UNSPECIFIED",
+	1: "EVENT_ENTER",
+	2: "EVENT_EXIT",
+	3: "EVENT_INSIDE",
+	4: "EVENT_DWELL",
+}
+
+var EventType_value = map[string]int32{
+	"EVENT_TYPE_UNSPECIFIED": 0,
+	"EVENT_ENTER":            1,
+	"EVENT_EXIT":             2,
+	"EVENT_INSIDE":           3,
+	"EVENT_DWELL":            4,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{4}
+}
+
 type Status int32
 
 const (
@@ -52,7 +201,7 @@ func (x Status) String() string {
 }
 
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{0}
+	return fileDescriptor_9b0d5848323ed639, []int{5}
 }
 
 // requests
@@ -61,6 +210,11 @@ type Point struct {
 	Latitude             float64  `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
 	Longitude            float64  `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
 	Accuracy             float64  `protobuf:"fixed64,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Altitude             float64  `protobuf:"fixed64,6,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	HasAltitude          bool     `protobuf:"varint,7,opt,name=has_altitude,json=hasAltitude,proto3" json:"has_altitude,omitempty"`
+	DeviceId             uint64   `protobuf:"varint,8,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId               uint64   `protobuf:"varint,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -119,10 +273,102 @@ func (m *Point) GetAccuracy() float64 {
 	return 0
 }
 
+func (m *Point) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Point) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *Point) GetHasAltitude() bool {
+	if m != nil {
+		return m.HasAltitude
+	}
+	return false
+}
+
+func (m *Point) GetDeviceId() uint64 {
+	if m != nil {
+		return m.DeviceId
+	}
+	return 0
+}
+
+func (m *Point) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+// условия отбора геозон, пустое условие не ограничивает отбор
+type Filter struct {
+	Categories           []string          `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	Tags                 []string          `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Filter) Reset()         { *m = Filter{} }
+func (m *Filter) String() string { return proto.CompactTextString(m) }
+func (*Filter) ProtoMessage()    {}
+func (*Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{1}
+}
+
+func (m *Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Filter.Unmarshal(m, b)
+}
+func (m *Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Filter.Marshal(b, m, deterministic)
+}
+func (m *Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Filter.Merge(m, src)
+}
+func (m *Filter) XXX_Size() int {
+	return xxx_messageInfo_Filter.Size(m)
+}
+func (m *Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Filter proto.InternalMessageInfo
+
+func (m *Filter) GetCategories() []string {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
+func (m *Filter) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Filter) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 type UserPoints struct {
 	UserId               uint64   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	WithDistance         bool     `protobuf:"varint,2,opt,name=with_distance,json=withDistance,proto3" json:"with_distance,omitempty"`
 	Items                []*Point `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Filter               *Filter  `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -132,7 +378,7 @@ func (m *UserPoints) Reset()         { *m = UserPoints{} }
 func (m *UserPoints) String() string { return proto.CompactTextString(m) }
 func (*UserPoints) ProtoMessage()    {}
 func (*UserPoints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{1}
+	return fileDescriptor_9b0d5848323ed639, []int{2}
 }
 
 func (m *UserPoints) XXX_Unmarshal(b []byte) error {
@@ -174,8 +420,20 @@ func (m *UserPoints) GetItems() []*Point {
 	return nil
 }
 
+func (m *UserPoints) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 type Points struct {
-	Points               []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	Points       []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	SearchRadius float64  `protobuf:"fixed64,2,opt,name=search_radius,json=searchRadius,proto3" json:"search_radius,omitempty"`
+	// геозоны, в которые точка не входит, а дистанция возвращается со знаком:
+	// отрицательная - внутри геозоны, положительная - снаружи
+	Filter               *Filter  `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	UserId               uint64   `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -185,7 +443,7 @@ func (m *Points) Reset()         { *m = Points{} }
 func (m *Points) String() string { return proto.CompactTextString(m) }
 func (*Points) ProtoMessage()    {}
 func (*Points) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{2}
+	return fileDescriptor_9b0d5848323ed639, []int{3}
 }
 
 func (m *Points) XXX_Unmarshal(b []byte) error {
@@ -213,9 +471,31 @@ func (m *Points) GetPoints() []*Point {
 	return nil
 }
 
+func (m *Points) GetSearchRadius() float64 {
+	if m != nil {
+		return m.SearchRadius
+	}
+	return 0
+}
+
+func (m *Points) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *Points) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
 type PointWithGeofence struct {
 	Points               []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
 	GeofenceId           []uint64 `protobuf:"varint,2,rep,packed,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	Filter               *Filter  `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -225,7 +505,7 @@ func (m *PointWithGeofence) Reset()         { *m = PointWithGeofence{} }
 func (m *PointWithGeofence) String() string { return proto.CompactTextString(m) }
 func (*PointWithGeofence) ProtoMessage()    {}
 func (*PointWithGeofence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{3}
+	return fileDescriptor_9b0d5848323ed639, []int{4}
 }
 
 func (m *PointWithGeofence) XXX_Unmarshal(b []byte) error {
@@ -260,281 +540,2709 @@ func (m *PointWithGeofence) GetGeofenceId() []uint64 {
 	return nil
 }
 
-// responses
-type GeofenceInfo struct {
-	GeofenceId           uint64   `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
-	PolygonId            uint64   `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Distance             float64  `protobuf:"fixed64,4,opt,name=distance,proto3" json:"distance,omitempty"`
+func (m *PointWithGeofence) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type NearestRequest struct {
+	Points               []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	UserId               uint64   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	MaxDistance          float64  `protobuf:"fixed64,4,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	Filter               *Filter  `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GeofenceInfo) Reset()         { *m = GeofenceInfo{} }
-func (m *GeofenceInfo) String() string { return proto.CompactTextString(m) }
-func (*GeofenceInfo) ProtoMessage()    {}
-func (*GeofenceInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{4}
+func (m *NearestRequest) Reset()         { *m = NearestRequest{} }
+func (m *NearestRequest) String() string { return proto.CompactTextString(m) }
+func (*NearestRequest) ProtoMessage()    {}
+func (*NearestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{5}
 }
 
-func (m *GeofenceInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GeofenceInfo.Unmarshal(m, b)
+func (m *NearestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NearestRequest.Unmarshal(m, b)
 }
-func (m *GeofenceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GeofenceInfo.Marshal(b, m, deterministic)
+func (m *NearestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NearestRequest.Marshal(b, m, deterministic)
 }
-func (m *GeofenceInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GeofenceInfo.Merge(m, src)
+func (m *NearestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NearestRequest.Merge(m, src)
 }
-func (m *GeofenceInfo) XXX_Size() int {
-	return xxx_messageInfo_GeofenceInfo.Size(m)
+func (m *NearestRequest) XXX_Size() int {
+	return xxx_messageInfo_NearestRequest.Size(m)
 }
-func (m *GeofenceInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_GeofenceInfo.DiscardUnknown(m)
+func (m *NearestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NearestRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GeofenceInfo proto.InternalMessageInfo
+var xxx_messageInfo_NearestRequest proto.InternalMessageInfo
 
-func (m *GeofenceInfo) GetGeofenceId() uint64 {
+func (m *NearestRequest) GetPoints() []*Point {
 	if m != nil {
-		return m.GeofenceId
+		return m.Points
 	}
-	return 0
+	return nil
 }
 
-func (m *GeofenceInfo) GetPolygonId() uint64 {
+func (m *NearestRequest) GetUserId() uint64 {
 	if m != nil {
-		return m.PolygonId
+		return m.UserId
 	}
 	return 0
 }
 
-func (m *GeofenceInfo) GetTitle() string {
+func (m *NearestRequest) GetLimit() uint32 {
 	if m != nil {
-		return m.Title
+		return m.Limit
 	}
-	return ""
+	return 0
 }
 
-func (m *GeofenceInfo) GetDistance() float64 {
+func (m *NearestRequest) GetMaxDistance() float64 {
 	if m != nil {
-		return m.Distance
+		return m.MaxDistance
 	}
 	return 0
 }
 
-type Geofence struct {
-	PointId              uint64          `protobuf:"varint,1,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
-	GeoInfo              []*GeofenceInfo `protobuf:"bytes,2,rep,name=geoInfo,proto3" json:"geoInfo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *NearestRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
 }
 
-func (m *Geofence) Reset()         { *m = Geofence{} }
-func (m *Geofence) String() string { return proto.CompactTextString(m) }
-func (*Geofence) ProtoMessage()    {}
-func (*Geofence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{5}
+type BoundsRequest struct {
+	MinLatitude          float64  `protobuf:"fixed64,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude         float64  `protobuf:"fixed64,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude          float64  `protobuf:"fixed64,3,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude         float64  `protobuf:"fixed64,4,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	UserId               uint64   `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Zoom                 uint32   `protobuf:"varint,6,opt,name=zoom,proto3" json:"zoom,omitempty"`
+	Filter               *Filter  `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Geofence) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Geofence.Unmarshal(m, b)
+func (m *BoundsRequest) Reset()         { *m = BoundsRequest{} }
+func (m *BoundsRequest) String() string { return proto.CompactTextString(m) }
+func (*BoundsRequest) ProtoMessage()    {}
+func (*BoundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{6}
 }
-func (m *Geofence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Geofence.Marshal(b, m, deterministic)
+
+func (m *BoundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BoundsRequest.Unmarshal(m, b)
 }
-func (m *Geofence) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Geofence.Merge(m, src)
+func (m *BoundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BoundsRequest.Marshal(b, m, deterministic)
 }
-func (m *Geofence) XXX_Size() int {
-	return xxx_messageInfo_Geofence.Size(m)
+func (m *BoundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BoundsRequest.Merge(m, src)
 }
-func (m *Geofence) XXX_DiscardUnknown() {
-	xxx_messageInfo_Geofence.DiscardUnknown(m)
+func (m *BoundsRequest) XXX_Size() int {
+	return xxx_messageInfo_BoundsRequest.Size(m)
+}
+func (m *BoundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BoundsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Geofence proto.InternalMessageInfo
+var xxx_messageInfo_BoundsRequest proto.InternalMessageInfo
 
-func (m *Geofence) GetPointId() uint64 {
+func (m *BoundsRequest) GetMinLatitude() float64 {
 	if m != nil {
-		return m.PointId
+		return m.MinLatitude
 	}
 	return 0
 }
 
-func (m *Geofence) GetGeoInfo() []*GeofenceInfo {
+func (m *BoundsRequest) GetMinLongitude() float64 {
 	if m != nil {
-		return m.GeoInfo
+		return m.MinLongitude
 	}
-	return nil
+	return 0
 }
 
-type Geofences struct {
-	UserId               uint64      `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Geofence             []*Geofence `protobuf:"bytes,2,rep,name=geofence,proto3" json:"geofence,omitempty"`
-	Status               Status      `protobuf:"varint,3,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
-	Error                string      `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *BoundsRequest) GetMaxLatitude() float64 {
+	if m != nil {
+		return m.MaxLatitude
+	}
+	return 0
 }
 
-func (m *Geofences) Reset()         { *m = Geofences{} }
-func (m *Geofences) String() string { return proto.CompactTextString(m) }
-func (*Geofences) ProtoMessage()    {}
-func (*Geofences) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9b0d5848323ed639, []int{6}
+func (m *BoundsRequest) GetMaxLongitude() float64 {
+	if m != nil {
+		return m.MaxLongitude
+	}
+	return 0
 }
 
-func (m *Geofences) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Geofences.Unmarshal(m, b)
-}
-func (m *Geofences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Geofences.Marshal(b, m, deterministic)
+func (m *BoundsRequest) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
 }
-func (m *Geofences) XXX_Merge(src proto.Message) {
+
+func (m *BoundsRequest) GetZoom() uint32 {
+	if m != nil {
+		return m.Zoom
+	}
+	return 0
+}
+
+func (m *BoundsRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type Track struct {
+	Points               []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	UserId               uint64   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter               *Filter  `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Track) Reset()         { *m = Track{} }
+func (m *Track) String() string { return proto.CompactTextString(m) }
+func (*Track) ProtoMessage()    {}
+func (*Track) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{7}
+}
+
+func (m *Track) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Track.Unmarshal(m, b)
+}
+func (m *Track) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Track.Marshal(b, m, deterministic)
+}
+func (m *Track) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Track.Merge(m, src)
+}
+func (m *Track) XXX_Size() int {
+	return xxx_messageInfo_Track.Size(m)
+}
+func (m *Track) XXX_DiscardUnknown() {
+	xxx_messageInfo_Track.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Track proto.InternalMessageInfo
+
+func (m *Track) GetPoints() []*Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+func (m *Track) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *Track) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type PolygonRequest struct {
+	// Types that are valid to be assigned to Geometry:
+	//	*PolygonRequest_Geojson
+	//	*PolygonRequest_Wkb
+	Geometry             isPolygonRequest_Geometry `protobuf_oneof:"geometry"`
+	UserId               uint64                    `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter               *Filter                   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *PolygonRequest) Reset()         { *m = PolygonRequest{} }
+func (m *PolygonRequest) String() string { return proto.CompactTextString(m) }
+func (*PolygonRequest) ProtoMessage()    {}
+func (*PolygonRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{8}
+}
+
+func (m *PolygonRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolygonRequest.Unmarshal(m, b)
+}
+func (m *PolygonRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolygonRequest.Marshal(b, m, deterministic)
+}
+func (m *PolygonRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolygonRequest.Merge(m, src)
+}
+func (m *PolygonRequest) XXX_Size() int {
+	return xxx_messageInfo_PolygonRequest.Size(m)
+}
+func (m *PolygonRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolygonRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolygonRequest proto.InternalMessageInfo
+
+type isPolygonRequest_Geometry interface {
+	isPolygonRequest_Geometry()
+}
+
+type PolygonRequest_Geojson struct {
+	Geojson string `protobuf:"bytes,1,opt,name=geojson,proto3,oneof"`
+}
+
+type PolygonRequest_Wkb struct {
+	Wkb []byte `protobuf:"bytes,2,opt,name=wkb,proto3,oneof"`
+}
+
+func (*PolygonRequest_Geojson) isPolygonRequest_Geometry() {}
+
+func (*PolygonRequest_Wkb) isPolygonRequest_Geometry() {}
+
+func (m *PolygonRequest) GetGeometry() isPolygonRequest_Geometry {
+	if m != nil {
+		return m.Geometry
+	}
+	return nil
+}
+
+func (m *PolygonRequest) GetGeojson() string {
+	if x, ok := m.GetGeometry().(*PolygonRequest_Geojson); ok {
+		return x.Geojson
+	}
+	return ""
+}
+
+func (m *PolygonRequest) GetWkb() []byte {
+	if x, ok := m.GetGeometry().(*PolygonRequest_Wkb); ok {
+		return x.Wkb
+	}
+	return nil
+}
+
+func (m *PolygonRequest) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *PolygonRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PolygonRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PolygonRequest_Geojson)(nil),
+		(*PolygonRequest_Wkb)(nil),
+	}
+}
+
+type GeofenceRequest struct {
+	GeofenceId           uint64         `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64         `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Format               GeometryFormat `protobuf:"varint,3,opt,name=format,proto3,enum=geofence.GeometryFormat" json:"format,omitempty"`
+	Filter               *Filter        `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GeofenceRequest) Reset()         { *m = GeofenceRequest{} }
+func (m *GeofenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GeofenceRequest) ProtoMessage()    {}
+func (*GeofenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{9}
+}
+
+func (m *GeofenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceRequest.Unmarshal(m, b)
+}
+func (m *GeofenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceRequest.Marshal(b, m, deterministic)
+}
+func (m *GeofenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceRequest.Merge(m, src)
+}
+func (m *GeofenceRequest) XXX_Size() int {
+	return xxx_messageInfo_GeofenceRequest.Size(m)
+}
+func (m *GeofenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceRequest proto.InternalMessageInfo
+
+func (m *GeofenceRequest) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *GeofenceRequest) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *GeofenceRequest) GetFormat() GeometryFormat {
+	if m != nil {
+		return m.Format
+	}
+	return GeometryFormat_GEOJSON
+}
+
+func (m *GeofenceRequest) GetFilter() *Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type DevicePoints struct {
+	Points               []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	UserId               uint64   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DevicePoints) Reset()         { *m = DevicePoints{} }
+func (m *DevicePoints) String() string { return proto.CompactTextString(m) }
+func (*DevicePoints) ProtoMessage()    {}
+func (*DevicePoints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{10}
+}
+
+func (m *DevicePoints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DevicePoints.Unmarshal(m, b)
+}
+func (m *DevicePoints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DevicePoints.Marshal(b, m, deterministic)
+}
+func (m *DevicePoints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DevicePoints.Merge(m, src)
+}
+func (m *DevicePoints) XXX_Size() int {
+	return xxx_messageInfo_DevicePoints.Size(m)
+}
+func (m *DevicePoints) XXX_DiscardUnknown() {
+	xxx_messageInfo_DevicePoints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DevicePoints proto.InternalMessageInfo
+
+func (m *DevicePoints) GetPoints() []*Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+func (m *DevicePoints) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+// подписка на переходы ENTER, EXIT и DWELL. Пустой список не ограничивает выборку
+type WatchRequest struct {
+	FromSequence         uint64   `protobuf:"varint,1,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	UserId               []uint64 `protobuf:"varint,2,rep,packed,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GeofenceId           []uint64 `protobuf:"varint,3,rep,packed,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	DeviceId             []uint64 `protobuf:"varint,4,rep,packed,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{11}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetFromSequence() uint64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+func (m *WatchRequest) GetUserId() []uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetGeofenceId() []uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetDeviceId() []uint64 {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+type QuarantineRequest struct {
+	UserId               uint64   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuarantineRequest) Reset()         { *m = QuarantineRequest{} }
+func (m *QuarantineRequest) String() string { return proto.CompactTextString(m) }
+func (*QuarantineRequest) ProtoMessage()    {}
+func (*QuarantineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{12}
+}
+
+func (m *QuarantineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantineRequest.Unmarshal(m, b)
+}
+func (m *QuarantineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantineRequest.Marshal(b, m, deterministic)
+}
+func (m *QuarantineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantineRequest.Merge(m, src)
+}
+func (m *QuarantineRequest) XXX_Size() int {
+	return xxx_messageInfo_QuarantineRequest.Size(m)
+}
+func (m *QuarantineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantineRequest proto.InternalMessageInfo
+
+func (m *QuarantineRequest) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+type DeadLettersRequest struct {
+	UserId               uint64   `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLettersRequest) Reset()         { *m = DeadLettersRequest{} }
+func (m *DeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*DeadLettersRequest) ProtoMessage()    {}
+func (*DeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{13}
+}
+
+func (m *DeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLettersRequest.Unmarshal(m, b)
+}
+func (m *DeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (m *DeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLettersRequest.Merge(m, src)
+}
+func (m *DeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_DeadLettersRequest.Size(m)
+}
+func (m *DeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLettersRequest proto.InternalMessageInfo
+
+func (m *DeadLettersRequest) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+// responses
+type GeofenceInfo struct {
+	GeofenceId       uint64            `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId        uint64            `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title            string            `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Distance         float64           `protobuf:"fixed64,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	Containment      Containment       `protobuf:"varint,5,opt,name=containment,proto3,enum=geofence.Containment" json:"containment,omitempty"`
+	RouteDistance    float64           `protobuf:"fixed64,6,opt,name=route_distance,json=routeDistance,proto3" json:"route_distance,omitempty"`
+	RouteProgress    float64           `protobuf:"fixed64,7,opt,name=route_progress,json=routeProgress,proto3" json:"route_progress,omitempty"`
+	VerticalDistance float64           `protobuf:"fixed64,8,opt,name=vertical_distance,json=verticalDistance,proto3" json:"vertical_distance,omitempty"`
+	Category         string            `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	Tags             []string          `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attributes       map[string]string `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ближайшая к точке точка границы геозоны, заполняется при расчете дистанции
+	NearestPoint         *Coordinates `protobuf:"bytes,12,opt,name=nearest_point,json=nearestPoint,proto3" json:"nearest_point,omitempty"`
+	Bearing              float64      `protobuf:"fixed64,13,opt,name=bearing,proto3" json:"bearing,omitempty"`
+	PolygonIndex         uint32       `protobuf:"varint,14,opt,name=polygon_index,json=polygonIndex,proto3" json:"polygon_index,omitempty"`
+	RingIndex            uint32       `protobuf:"varint,15,opt,name=ring_index,json=ringIndex,proto3" json:"ring_index,omitempty"`
+	EdgeIndex            uint32       `protobuf:"varint,16,opt,name=edge_index,json=edgeIndex,proto3" json:"edge_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GeofenceInfo) Reset()         { *m = GeofenceInfo{} }
+func (m *GeofenceInfo) String() string { return proto.CompactTextString(m) }
+func (*GeofenceInfo) ProtoMessage()    {}
+func (*GeofenceInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{14}
+}
+
+func (m *GeofenceInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceInfo.Unmarshal(m, b)
+}
+func (m *GeofenceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceInfo.Marshal(b, m, deterministic)
+}
+func (m *GeofenceInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceInfo.Merge(m, src)
+}
+func (m *GeofenceInfo) XXX_Size() int {
+	return xxx_messageInfo_GeofenceInfo.Size(m)
+}
+func (m *GeofenceInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceInfo proto.InternalMessageInfo
+
+func (m *GeofenceInfo) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *GeofenceInfo) GetDistance() float64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetContainment() Containment {
+	if m != nil {
+		return m.Containment
+	}
+	return Containment_CONTAINMENT_UNSPECIFIED
+}
+
+func (m *GeofenceInfo) GetRouteDistance() float64 {
+	if m != nil {
+		return m.RouteDistance
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetRouteProgress() float64 {
+	if m != nil {
+		return m.RouteProgress
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetVerticalDistance() float64 {
+	if m != nil {
+		return m.VerticalDistance
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *GeofenceInfo) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *GeofenceInfo) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *GeofenceInfo) GetNearestPoint() *Coordinates {
+	if m != nil {
+		return m.NearestPoint
+	}
+	return nil
+}
+
+func (m *GeofenceInfo) GetBearing() float64 {
+	if m != nil {
+		return m.Bearing
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetPolygonIndex() uint32 {
+	if m != nil {
+		return m.PolygonIndex
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetRingIndex() uint32 {
+	if m != nil {
+		return m.RingIndex
+	}
+	return 0
+}
+
+func (m *GeofenceInfo) GetEdgeIndex() uint32 {
+	if m != nil {
+		return m.EdgeIndex
+	}
+	return 0
+}
+
+type Geofence struct {
+	PointId              uint64          `protobuf:"varint,1,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	GeoInfo              []*GeofenceInfo `protobuf:"bytes,2,rep,name=geoInfo,proto3" json:"geoInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Geofence) Reset()         { *m = Geofence{} }
+func (m *Geofence) String() string { return proto.CompactTextString(m) }
+func (*Geofence) ProtoMessage()    {}
+func (*Geofence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{15}
+}
+
+func (m *Geofence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Geofence.Unmarshal(m, b)
+}
+func (m *Geofence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Geofence.Marshal(b, m, deterministic)
+}
+func (m *Geofence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Geofence.Merge(m, src)
+}
+func (m *Geofence) XXX_Size() int {
+	return xxx_messageInfo_Geofence.Size(m)
+}
+func (m *Geofence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Geofence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Geofence proto.InternalMessageInfo
+
+func (m *Geofence) GetPointId() uint64 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+func (m *Geofence) GetGeoInfo() []*GeofenceInfo {
+	if m != nil {
+		return m.GeoInfo
+	}
+	return nil
+}
+
+type Geofences struct {
+	UserId               uint64      `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Geofence             []*Geofence `protobuf:"bytes,2,rep,name=geofence,proto3" json:"geofence,omitempty"`
+	Status               Status      `protobuf:"varint,3,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string      `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Geofences) Reset()         { *m = Geofences{} }
+func (m *Geofences) String() string { return proto.CompactTextString(m) }
+func (*Geofences) ProtoMessage()    {}
+func (*Geofences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{16}
+}
+
+func (m *Geofences) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Geofences.Unmarshal(m, b)
+}
+func (m *Geofences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Geofences.Marshal(b, m, deterministic)
+}
+func (m *Geofences) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_Geofences.Merge(m, src)
 }
-func (m *Geofences) XXX_Size() int {
-	return xxx_messageInfo_Geofences.Size(m)
+func (m *Geofences) XXX_Size() int {
+	return xxx_messageInfo_Geofences.Size(m)
+}
+func (m *Geofences) XXX_DiscardUnknown() {
+	xxx_messageInfo_Geofences.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Geofences proto.InternalMessageInfo
+
+func (m *Geofences) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *Geofences) GetGeofence() []*Geofence {
+	if m != nil {
+		return m.Geofence
+	}
+	return nil
+}
+
+func (m *Geofences) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *Geofences) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GeofenceGeometry struct {
+	GeofenceId           uint64       `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64       `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string       `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	UserId               uint64       `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind                 GeofenceKind `protobuf:"varint,5,opt,name=kind,proto3,enum=geofence.GeofenceKind" json:"kind,omitempty"`
+	Geometry             string       `protobuf:"bytes,6,opt,name=geometry,proto3" json:"geometry,omitempty"`
+	Radius               float64      `protobuf:"fixed64,7,opt,name=radius,proto3" json:"radius,omitempty"`
+	Width                float64      `protobuf:"fixed64,8,opt,name=width,proto3" json:"width,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GeofenceGeometry) Reset()         { *m = GeofenceGeometry{} }
+func (m *GeofenceGeometry) String() string { return proto.CompactTextString(m) }
+func (*GeofenceGeometry) ProtoMessage()    {}
+func (*GeofenceGeometry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{17}
+}
+
+func (m *GeofenceGeometry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceGeometry.Unmarshal(m, b)
+}
+func (m *GeofenceGeometry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceGeometry.Marshal(b, m, deterministic)
+}
+func (m *GeofenceGeometry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceGeometry.Merge(m, src)
+}
+func (m *GeofenceGeometry) XXX_Size() int {
+	return xxx_messageInfo_GeofenceGeometry.Size(m)
+}
+func (m *GeofenceGeometry) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceGeometry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceGeometry proto.InternalMessageInfo
+
+func (m *GeofenceGeometry) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *GeofenceGeometry) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *GeofenceGeometry) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *GeofenceGeometry) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *GeofenceGeometry) GetKind() GeofenceKind {
+	if m != nil {
+		return m.Kind
+	}
+	return GeofenceKind_POLYGON
+}
+
+func (m *GeofenceGeometry) GetGeometry() string {
+	if m != nil {
+		return m.Geometry
+	}
+	return ""
+}
+
+func (m *GeofenceGeometry) GetRadius() float64 {
+	if m != nil {
+		return m.Radius
+	}
+	return 0
+}
+
+func (m *GeofenceGeometry) GetWidth() float64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+type GeofenceGeometries struct {
+	Geofences            []*GeofenceGeometry `protobuf:"bytes,1,rep,name=geofences,proto3" json:"geofences,omitempty"`
+	Status               Status              `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string              `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GeofenceGeometries) Reset()         { *m = GeofenceGeometries{} }
+func (m *GeofenceGeometries) String() string { return proto.CompactTextString(m) }
+func (*GeofenceGeometries) ProtoMessage()    {}
+func (*GeofenceGeometries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{18}
+}
+
+func (m *GeofenceGeometries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceGeometries.Unmarshal(m, b)
+}
+func (m *GeofenceGeometries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceGeometries.Marshal(b, m, deterministic)
+}
+func (m *GeofenceGeometries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceGeometries.Merge(m, src)
+}
+func (m *GeofenceGeometries) XXX_Size() int {
+	return xxx_messageInfo_GeofenceGeometries.Size(m)
+}
+func (m *GeofenceGeometries) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceGeometries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceGeometries proto.InternalMessageInfo
+
+func (m *GeofenceGeometries) GetGeofences() []*GeofenceGeometry {
+	if m != nil {
+		return m.Geofences
+	}
+	return nil
+}
+
+func (m *GeofenceGeometries) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *GeofenceGeometries) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type TrackPoint struct {
+	Latitude             float64  `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64  `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrackPoint) Reset()         { *m = TrackPoint{} }
+func (m *TrackPoint) String() string { return proto.CompactTextString(m) }
+func (*TrackPoint) ProtoMessage()    {}
+func (*TrackPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{19}
+}
+
+func (m *TrackPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrackPoint.Unmarshal(m, b)
+}
+func (m *TrackPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrackPoint.Marshal(b, m, deterministic)
+}
+func (m *TrackPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrackPoint.Merge(m, src)
+}
+func (m *TrackPoint) XXX_Size() int {
+	return xxx_messageInfo_TrackPoint.Size(m)
+}
+func (m *TrackPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrackPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrackPoint proto.InternalMessageInfo
+
+func (m *TrackPoint) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *TrackPoint) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *TrackPoint) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type Crossing struct {
+	GeofenceId           uint64      `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64      `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string      `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Entry                *TrackPoint `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+	Exit                 *TrackPoint `protobuf:"bytes,5,opt,name=exit,proto3" json:"exit,omitempty"`
+	StartedInside        bool        `protobuf:"varint,6,opt,name=started_inside,json=startedInside,proto3" json:"started_inside,omitempty"`
+	EndedInside          bool        `protobuf:"varint,7,opt,name=ended_inside,json=endedInside,proto3" json:"ended_inside,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Crossing) Reset()         { *m = Crossing{} }
+func (m *Crossing) String() string { return proto.CompactTextString(m) }
+func (*Crossing) ProtoMessage()    {}
+func (*Crossing) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{20}
+}
+
+func (m *Crossing) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Crossing.Unmarshal(m, b)
+}
+func (m *Crossing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Crossing.Marshal(b, m, deterministic)
+}
+func (m *Crossing) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Crossing.Merge(m, src)
+}
+func (m *Crossing) XXX_Size() int {
+	return xxx_messageInfo_Crossing.Size(m)
+}
+func (m *Crossing) XXX_DiscardUnknown() {
+	xxx_messageInfo_Crossing.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Crossing proto.InternalMessageInfo
+
+func (m *Crossing) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *Crossing) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *Crossing) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Crossing) GetEntry() *TrackPoint {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *Crossing) GetExit() *TrackPoint {
+	if m != nil {
+		return m.Exit
+	}
+	return nil
+}
+
+func (m *Crossing) GetStartedInside() bool {
+	if m != nil {
+		return m.StartedInside
+	}
+	return false
+}
+
+func (m *Crossing) GetEndedInside() bool {
+	if m != nil {
+		return m.EndedInside
+	}
+	return false
+}
+
+type TrackCrossings struct {
+	Crossings            []*Crossing `protobuf:"bytes,1,rep,name=crossings,proto3" json:"crossings,omitempty"`
+	Status               Status      `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string      `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TrackCrossings) Reset()         { *m = TrackCrossings{} }
+func (m *TrackCrossings) String() string { return proto.CompactTextString(m) }
+func (*TrackCrossings) ProtoMessage()    {}
+func (*TrackCrossings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{21}
+}
+
+func (m *TrackCrossings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrackCrossings.Unmarshal(m, b)
+}
+func (m *TrackCrossings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrackCrossings.Marshal(b, m, deterministic)
+}
+func (m *TrackCrossings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrackCrossings.Merge(m, src)
+}
+func (m *TrackCrossings) XXX_Size() int {
+	return xxx_messageInfo_TrackCrossings.Size(m)
+}
+func (m *TrackCrossings) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrackCrossings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrackCrossings proto.InternalMessageInfo
+
+func (m *TrackCrossings) GetCrossings() []*Crossing {
+	if m != nil {
+		return m.Crossings
+	}
+	return nil
+}
+
+func (m *TrackCrossings) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *TrackCrossings) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type PolygonRelation struct {
+	GeofenceId           uint64   `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64   `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Relation             Relation `protobuf:"varint,4,opt,name=relation,proto3,enum=geofence.Relation" json:"relation,omitempty"`
+	OverlapArea          float64  `protobuf:"fixed64,5,opt,name=overlap_area,json=overlapArea,proto3" json:"overlap_area,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolygonRelation) Reset()         { *m = PolygonRelation{} }
+func (m *PolygonRelation) String() string { return proto.CompactTextString(m) }
+func (*PolygonRelation) ProtoMessage()    {}
+func (*PolygonRelation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{22}
+}
+
+func (m *PolygonRelation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolygonRelation.Unmarshal(m, b)
+}
+func (m *PolygonRelation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolygonRelation.Marshal(b, m, deterministic)
+}
+func (m *PolygonRelation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolygonRelation.Merge(m, src)
+}
+func (m *PolygonRelation) XXX_Size() int {
+	return xxx_messageInfo_PolygonRelation.Size(m)
+}
+func (m *PolygonRelation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolygonRelation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolygonRelation proto.InternalMessageInfo
+
+func (m *PolygonRelation) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *PolygonRelation) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *PolygonRelation) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *PolygonRelation) GetRelation() Relation {
+	if m != nil {
+		return m.Relation
+	}
+	return Relation_RELATION_UNSPECIFIED
+}
+
+func (m *PolygonRelation) GetOverlapArea() float64 {
+	if m != nil {
+		return m.OverlapArea
+	}
+	return 0
+}
+
+type PolygonRelations struct {
+	Relations            []*PolygonRelation `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	Status               Status             `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string             `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PolygonRelations) Reset()         { *m = PolygonRelations{} }
+func (m *PolygonRelations) String() string { return proto.CompactTextString(m) }
+func (*PolygonRelations) ProtoMessage()    {}
+func (*PolygonRelations) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{23}
+}
+
+func (m *PolygonRelations) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolygonRelations.Unmarshal(m, b)
+}
+func (m *PolygonRelations) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolygonRelations.Marshal(b, m, deterministic)
+}
+func (m *PolygonRelations) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolygonRelations.Merge(m, src)
+}
+func (m *PolygonRelations) XXX_Size() int {
+	return xxx_messageInfo_PolygonRelations.Size(m)
+}
+func (m *PolygonRelations) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolygonRelations.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolygonRelations proto.InternalMessageInfo
+
+func (m *PolygonRelations) GetRelations() []*PolygonRelation {
+	if m != nil {
+		return m.Relations
+	}
+	return nil
+}
+
+func (m *PolygonRelations) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *PolygonRelations) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Coordinates struct {
+	Latitude             float64  `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64  `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Coordinates) Reset()         { *m = Coordinates{} }
+func (m *Coordinates) String() string { return proto.CompactTextString(m) }
+func (*Coordinates) ProtoMessage()    {}
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{24}
+}
+
+func (m *Coordinates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Coordinates.Unmarshal(m, b)
+}
+func (m *Coordinates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Coordinates.Marshal(b, m, deterministic)
+}
+func (m *Coordinates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Coordinates.Merge(m, src)
+}
+func (m *Coordinates) XXX_Size() int {
+	return xxx_messageInfo_Coordinates.Size(m)
+}
+func (m *Coordinates) XXX_DiscardUnknown() {
+	xxx_messageInfo_Coordinates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Coordinates proto.InternalMessageInfo
+
+func (m *Coordinates) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *Coordinates) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+type BoundingBox struct {
+	Min                  *Coordinates `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max                  *Coordinates `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *BoundingBox) Reset()         { *m = BoundingBox{} }
+func (m *BoundingBox) String() string { return proto.CompactTextString(m) }
+func (*BoundingBox) ProtoMessage()    {}
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{25}
+}
+
+func (m *BoundingBox) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BoundingBox.Unmarshal(m, b)
+}
+func (m *BoundingBox) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BoundingBox.Marshal(b, m, deterministic)
+}
+func (m *BoundingBox) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BoundingBox.Merge(m, src)
+}
+func (m *BoundingBox) XXX_Size() int {
+	return xxx_messageInfo_BoundingBox.Size(m)
+}
+func (m *BoundingBox) XXX_DiscardUnknown() {
+	xxx_messageInfo_BoundingBox.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BoundingBox proto.InternalMessageInfo
+
+func (m *BoundingBox) GetMin() *Coordinates {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *BoundingBox) GetMax() *Coordinates {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+type PolygonDetails struct {
+	GeofenceId uint64       `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId  uint64       `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title      string       `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	UserId     uint64       `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kind       GeofenceKind `protobuf:"varint,5,opt,name=kind,proto3,enum=geofence.GeofenceKind" json:"kind,omitempty"`
+	// Types that are valid to be assigned to Geometry:
+	//	*PolygonDetails_Geojson
+	//	*PolygonDetails_Wkb
+	Geometry             isPolygonDetails_Geometry `protobuf_oneof:"geometry"`
+	Radius               float64                   `protobuf:"fixed64,8,opt,name=radius,proto3" json:"radius,omitempty"`
+	Width                float64                   `protobuf:"fixed64,9,opt,name=width,proto3" json:"width,omitempty"`
+	Area                 float64                   `protobuf:"fixed64,10,opt,name=area,proto3" json:"area,omitempty"`
+	Perimeter            float64                   `protobuf:"fixed64,11,opt,name=perimeter,proto3" json:"perimeter,omitempty"`
+	Centroid             *Coordinates              `protobuf:"bytes,12,opt,name=centroid,proto3" json:"centroid,omitempty"`
+	BoundingBox          *BoundingBox              `protobuf:"bytes,13,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *PolygonDetails) Reset()         { *m = PolygonDetails{} }
+func (m *PolygonDetails) String() string { return proto.CompactTextString(m) }
+func (*PolygonDetails) ProtoMessage()    {}
+func (*PolygonDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{26}
+}
+
+func (m *PolygonDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolygonDetails.Unmarshal(m, b)
+}
+func (m *PolygonDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolygonDetails.Marshal(b, m, deterministic)
+}
+func (m *PolygonDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolygonDetails.Merge(m, src)
+}
+func (m *PolygonDetails) XXX_Size() int {
+	return xxx_messageInfo_PolygonDetails.Size(m)
+}
+func (m *PolygonDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolygonDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolygonDetails proto.InternalMessageInfo
+
+func (m *PolygonDetails) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *PolygonDetails) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetKind() GeofenceKind {
+	if m != nil {
+		return m.Kind
+	}
+	return GeofenceKind_POLYGON
+}
+
+type isPolygonDetails_Geometry interface {
+	isPolygonDetails_Geometry()
+}
+
+type PolygonDetails_Geojson struct {
+	Geojson string `protobuf:"bytes,6,opt,name=geojson,proto3,oneof"`
+}
+
+type PolygonDetails_Wkb struct {
+	Wkb []byte `protobuf:"bytes,7,opt,name=wkb,proto3,oneof"`
+}
+
+func (*PolygonDetails_Geojson) isPolygonDetails_Geometry() {}
+
+func (*PolygonDetails_Wkb) isPolygonDetails_Geometry() {}
+
+func (m *PolygonDetails) GetGeometry() isPolygonDetails_Geometry {
+	if m != nil {
+		return m.Geometry
+	}
+	return nil
+}
+
+func (m *PolygonDetails) GetGeojson() string {
+	if x, ok := m.GetGeometry().(*PolygonDetails_Geojson); ok {
+		return x.Geojson
+	}
+	return ""
+}
+
+func (m *PolygonDetails) GetWkb() []byte {
+	if x, ok := m.GetGeometry().(*PolygonDetails_Wkb); ok {
+		return x.Wkb
+	}
+	return nil
+}
+
+func (m *PolygonDetails) GetRadius() float64 {
+	if m != nil {
+		return m.Radius
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetWidth() float64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetArea() float64 {
+	if m != nil {
+		return m.Area
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetPerimeter() float64 {
+	if m != nil {
+		return m.Perimeter
+	}
+	return 0
+}
+
+func (m *PolygonDetails) GetCentroid() *Coordinates {
+	if m != nil {
+		return m.Centroid
+	}
+	return nil
+}
+
+func (m *PolygonDetails) GetBoundingBox() *BoundingBox {
+	if m != nil {
+		return m.BoundingBox
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PolygonDetails) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PolygonDetails_Geojson)(nil),
+		(*PolygonDetails_Wkb)(nil),
+	}
+}
+
+type GeofenceDetails struct {
+	Polygons             []*PolygonDetails `protobuf:"bytes,1,rep,name=polygons,proto3" json:"polygons,omitempty"`
+	Status               Status            `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string            `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GeofenceDetails) Reset()         { *m = GeofenceDetails{} }
+func (m *GeofenceDetails) String() string { return proto.CompactTextString(m) }
+func (*GeofenceDetails) ProtoMessage()    {}
+func (*GeofenceDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{27}
+}
+
+func (m *GeofenceDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceDetails.Unmarshal(m, b)
+}
+func (m *GeofenceDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceDetails.Marshal(b, m, deterministic)
+}
+func (m *GeofenceDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceDetails.Merge(m, src)
+}
+func (m *GeofenceDetails) XXX_Size() int {
+	return xxx_messageInfo_GeofenceDetails.Size(m)
+}
+func (m *GeofenceDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceDetails proto.InternalMessageInfo
+
+func (m *GeofenceDetails) GetPolygons() []*PolygonDetails {
+	if m != nil {
+		return m.Polygons
+	}
+	return nil
+}
+
+func (m *GeofenceDetails) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *GeofenceDetails) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DeviceEvent struct {
+	DeviceId             uint64      `protobuf:"varint,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	PointId              uint64      `protobuf:"varint,2,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	GeofenceId           uint64      `protobuf:"varint,3,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64      `protobuf:"varint,4,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string      `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Type                 EventType   `protobuf:"varint,6,opt,name=type,proto3,enum=geofence.EventType" json:"type,omitempty"`
+	Point                *TrackPoint `protobuf:"bytes,7,opt,name=point,proto3" json:"point,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DeviceEvent) Reset()         { *m = DeviceEvent{} }
+func (m *DeviceEvent) String() string { return proto.CompactTextString(m) }
+func (*DeviceEvent) ProtoMessage()    {}
+func (*DeviceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{28}
+}
+
+func (m *DeviceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceEvent.Unmarshal(m, b)
+}
+func (m *DeviceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceEvent.Marshal(b, m, deterministic)
+}
+func (m *DeviceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceEvent.Merge(m, src)
+}
+func (m *DeviceEvent) XXX_Size() int {
+	return xxx_messageInfo_DeviceEvent.Size(m)
+}
+func (m *DeviceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceEvent proto.InternalMessageInfo
+
+func (m *DeviceEvent) GetDeviceId() uint64 {
+	if m != nil {
+		return m.DeviceId
+	}
+	return 0
+}
+
+func (m *DeviceEvent) GetPointId() uint64 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+func (m *DeviceEvent) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *DeviceEvent) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *DeviceEvent) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *DeviceEvent) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *DeviceEvent) GetPoint() *TrackPoint {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+type DeviceEvents struct {
+	Events               []*DeviceEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	StalePoints          []uint64       `protobuf:"varint,2,rep,packed,name=stale_points,json=stalePoints,proto3" json:"stale_points,omitempty"`
+	Status               Status         `protobuf:"varint,3,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string         `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeviceEvents) Reset()         { *m = DeviceEvents{} }
+func (m *DeviceEvents) String() string { return proto.CompactTextString(m) }
+func (*DeviceEvents) ProtoMessage()    {}
+func (*DeviceEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{29}
+}
+
+func (m *DeviceEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceEvents.Unmarshal(m, b)
+}
+func (m *DeviceEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceEvents.Marshal(b, m, deterministic)
+}
+func (m *DeviceEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceEvents.Merge(m, src)
+}
+func (m *DeviceEvents) XXX_Size() int {
+	return xxx_messageInfo_DeviceEvents.Size(m)
+}
+func (m *DeviceEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceEvents proto.InternalMessageInfo
+
+func (m *DeviceEvents) GetEvents() []*DeviceEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *DeviceEvents) GetStalePoints() []uint64 {
+	if m != nil {
+		return m.StalePoints
+	}
+	return nil
+}
+
+func (m *DeviceEvents) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *DeviceEvents) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// ответ на точку потока координат: для точки с device_id - переходы устройства, иначе - геозоны, в которые
+// попадает точка. Ошибка в точке не прерывает поток и возвращается в полях status и error
+type PositionUpdate struct {
+	PointId              uint64          `protobuf:"varint,1,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	DeviceId             uint64          `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Geofences            []*GeofenceInfo `protobuf:"bytes,3,rep,name=geofences,proto3" json:"geofences,omitempty"`
+	Events               []*DeviceEvent  `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	Stale                bool            `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	Status               Status          `protobuf:"varint,6,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string          `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PositionUpdate) Reset()         { *m = PositionUpdate{} }
+func (m *PositionUpdate) String() string { return proto.CompactTextString(m) }
+func (*PositionUpdate) ProtoMessage()    {}
+func (*PositionUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{30}
+}
+
+func (m *PositionUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PositionUpdate.Unmarshal(m, b)
+}
+func (m *PositionUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PositionUpdate.Marshal(b, m, deterministic)
+}
+func (m *PositionUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PositionUpdate.Merge(m, src)
+}
+func (m *PositionUpdate) XXX_Size() int {
+	return xxx_messageInfo_PositionUpdate.Size(m)
+}
+func (m *PositionUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PositionUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PositionUpdate proto.InternalMessageInfo
+
+func (m *PositionUpdate) GetPointId() uint64 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+func (m *PositionUpdate) GetDeviceId() uint64 {
+	if m != nil {
+		return m.DeviceId
+	}
+	return 0
+}
+
+func (m *PositionUpdate) GetGeofences() []*GeofenceInfo {
+	if m != nil {
+		return m.Geofences
+	}
+	return nil
+}
+
+func (m *PositionUpdate) GetEvents() []*DeviceEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *PositionUpdate) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+func (m *PositionUpdate) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *PositionUpdate) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// событие подписки. Если события с from_sequence уже вытеснены из буфера сервиса, то поток начинается
+// с самого старого сохраненного события, пропуск определяется по номеру sequence
+type GeofenceEvent struct {
+	Sequence             uint64      `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	DeviceId             uint64      `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	UserId               uint64      `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GeofenceId           uint64      `protobuf:"varint,4,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64      `protobuf:"varint,5,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string      `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Type                 EventType   `protobuf:"varint,7,opt,name=type,proto3,enum=geofence.EventType" json:"type,omitempty"`
+	Point                *TrackPoint `protobuf:"bytes,8,opt,name=point,proto3" json:"point,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GeofenceEvent) Reset()         { *m = GeofenceEvent{} }
+func (m *GeofenceEvent) String() string { return proto.CompactTextString(m) }
+func (*GeofenceEvent) ProtoMessage()    {}
+func (*GeofenceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{31}
+}
+
+func (m *GeofenceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeofenceEvent.Unmarshal(m, b)
+}
+func (m *GeofenceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeofenceEvent.Marshal(b, m, deterministic)
+}
+func (m *GeofenceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeofenceEvent.Merge(m, src)
+}
+func (m *GeofenceEvent) XXX_Size() int {
+	return xxx_messageInfo_GeofenceEvent.Size(m)
+}
+func (m *GeofenceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeofenceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeofenceEvent proto.InternalMessageInfo
+
+func (m *GeofenceEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *GeofenceEvent) GetDeviceId() uint64 {
+	if m != nil {
+		return m.DeviceId
+	}
+	return 0
+}
+
+func (m *GeofenceEvent) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *GeofenceEvent) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *GeofenceEvent) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *GeofenceEvent) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *GeofenceEvent) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (m *GeofenceEvent) GetPoint() *TrackPoint {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+type GeometryIssue struct {
+	Defect               string       `protobuf:"bytes,1,opt,name=defect,proto3" json:"defect,omitempty"`
+	PolygonIndex         uint32       `protobuf:"varint,2,opt,name=polygon_index,json=polygonIndex,proto3" json:"polygon_index,omitempty"`
+	RingIndex            uint32       `protobuf:"varint,3,opt,name=ring_index,json=ringIndex,proto3" json:"ring_index,omitempty"`
+	Point                *Coordinates `protobuf:"bytes,4,opt,name=point,proto3" json:"point,omitempty"`
+	Repaired             bool         `protobuf:"varint,5,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GeometryIssue) Reset()         { *m = GeometryIssue{} }
+func (m *GeometryIssue) String() string { return proto.CompactTextString(m) }
+func (*GeometryIssue) ProtoMessage()    {}
+func (*GeometryIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{32}
+}
+
+func (m *GeometryIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeometryIssue.Unmarshal(m, b)
+}
+func (m *GeometryIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeometryIssue.Marshal(b, m, deterministic)
+}
+func (m *GeometryIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeometryIssue.Merge(m, src)
+}
+func (m *GeometryIssue) XXX_Size() int {
+	return xxx_messageInfo_GeometryIssue.Size(m)
+}
+func (m *GeometryIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeometryIssue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeometryIssue proto.InternalMessageInfo
+
+func (m *GeometryIssue) GetDefect() string {
+	if m != nil {
+		return m.Defect
+	}
+	return ""
 }
-func (m *Geofences) XXX_DiscardUnknown() {
-	xxx_messageInfo_Geofences.DiscardUnknown(m)
+
+func (m *GeometryIssue) GetPolygonIndex() uint32 {
+	if m != nil {
+		return m.PolygonIndex
+	}
+	return 0
 }
 
-var xxx_messageInfo_Geofences proto.InternalMessageInfo
+func (m *GeometryIssue) GetRingIndex() uint32 {
+	if m != nil {
+		return m.RingIndex
+	}
+	return 0
+}
 
-func (m *Geofences) GetUserId() uint64 {
+func (m *GeometryIssue) GetPoint() *Coordinates {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+func (m *GeometryIssue) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+type QuarantinedGeofence struct {
+	GeofenceId           uint64           `protobuf:"varint,1,opt,name=geofence_id,json=geofenceId,proto3" json:"geofence_id,omitempty"`
+	PolygonId            uint64           `protobuf:"varint,2,opt,name=polygon_id,json=polygonId,proto3" json:"polygon_id,omitempty"`
+	Title                string           `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	UserId               uint64           `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason               string           `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Issues               []*GeometryIssue `protobuf:"bytes,6,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *QuarantinedGeofence) Reset()         { *m = QuarantinedGeofence{} }
+func (m *QuarantinedGeofence) String() string { return proto.CompactTextString(m) }
+func (*QuarantinedGeofence) ProtoMessage()    {}
+func (*QuarantinedGeofence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{33}
+}
+
+func (m *QuarantinedGeofence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantinedGeofence.Unmarshal(m, b)
+}
+func (m *QuarantinedGeofence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantinedGeofence.Marshal(b, m, deterministic)
+}
+func (m *QuarantinedGeofence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedGeofence.Merge(m, src)
+}
+func (m *QuarantinedGeofence) XXX_Size() int {
+	return xxx_messageInfo_QuarantinedGeofence.Size(m)
+}
+func (m *QuarantinedGeofence) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedGeofence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedGeofence proto.InternalMessageInfo
+
+func (m *QuarantinedGeofence) GetGeofenceId() uint64 {
+	if m != nil {
+		return m.GeofenceId
+	}
+	return 0
+}
+
+func (m *QuarantinedGeofence) GetPolygonId() uint64 {
+	if m != nil {
+		return m.PolygonId
+	}
+	return 0
+}
+
+func (m *QuarantinedGeofence) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *QuarantinedGeofence) GetUserId() uint64 {
 	if m != nil {
 		return m.UserId
 	}
 	return 0
 }
 
-func (m *Geofences) GetGeofence() []*Geofence {
+func (m *QuarantinedGeofence) GetReason() string {
 	if m != nil {
-		return m.Geofence
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *QuarantinedGeofence) GetIssues() []*GeometryIssue {
+	if m != nil {
+		return m.Issues
 	}
 	return nil
 }
 
-func (m *Geofences) GetStatus() Status {
+type Quarantine struct {
+	Geofences            []*QuarantinedGeofence `protobuf:"bytes,1,rep,name=geofences,proto3" json:"geofences,omitempty"`
+	Status               Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *Quarantine) Reset()         { *m = Quarantine{} }
+func (m *Quarantine) String() string { return proto.CompactTextString(m) }
+func (*Quarantine) ProtoMessage()    {}
+func (*Quarantine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{34}
+}
+
+func (m *Quarantine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quarantine.Unmarshal(m, b)
+}
+func (m *Quarantine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quarantine.Marshal(b, m, deterministic)
+}
+func (m *Quarantine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quarantine.Merge(m, src)
+}
+func (m *Quarantine) XXX_Size() int {
+	return xxx_messageInfo_Quarantine.Size(m)
+}
+func (m *Quarantine) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quarantine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quarantine proto.InternalMessageInfo
+
+func (m *Quarantine) GetGeofences() []*QuarantinedGeofence {
+	if m != nil {
+		return m.Geofences
+	}
+	return nil
+}
+
+func (m *Quarantine) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_OK
+}
+
+func (m *Quarantine) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// событие, которое не удалось доставить на webhook пользователя
+type DeadLetter struct {
+	DeliveryId           uint64         `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	UserId               uint64         `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url                  string         `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Event                *GeofenceEvent `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	Attempts             uint32         `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError            string         `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	FailedAt             int64          `protobuf:"varint,7,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{35}
+}
+
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (m *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(m, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetDeliveryId() uint64 {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetEvent() *GeofenceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetFailedAt() int64 {
+	if m != nil {
+		return m.FailedAt
+	}
+	return 0
+}
+
+type DeadLetters struct {
+	Letters              []*DeadLetter `protobuf:"bytes,1,rep,name=letters,proto3" json:"letters,omitempty"`
+	Status               Status        `protobuf:"varint,2,opt,name=status,proto3,enum=geofence.Status" json:"status,omitempty"`
+	Error                string        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DeadLetters) Reset()         { *m = DeadLetters{} }
+func (m *DeadLetters) String() string { return proto.CompactTextString(m) }
+func (*DeadLetters) ProtoMessage()    {}
+func (*DeadLetters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b0d5848323ed639, []int{36}
+}
+
+func (m *DeadLetters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetters.Unmarshal(m, b)
+}
+func (m *DeadLetters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetters.Marshal(b, m, deterministic)
+}
+func (m *DeadLetters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetters.Merge(m, src)
+}
+func (m *DeadLetters) XXX_Size() int {
+	return xxx_messageInfo_DeadLetters.Size(m)
+}
+func (m *DeadLetters) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetters proto.InternalMessageInfo
+
+func (m *DeadLetters) GetLetters() []*DeadLetter {
+	if m != nil {
+		return m.Letters
+	}
+	return nil
+}
+
+func (m *DeadLetters) GetStatus() Status {
 	if m != nil {
 		return m.Status
 	}
 	return Status_OK
 }
 
-func (m *Geofences) GetError() string {
-	if m != nil {
-		return m.Error
+func (m *DeadLetters) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("geofence.GeometryFormat", GeometryFormat_name, GeometryFormat_value)
+	proto.RegisterEnum("geofence.Relation", Relation_name, Relation_value)
+	proto.RegisterEnum("geofence.GeofenceKind", GeofenceKind_name, GeofenceKind_value)
+	proto.RegisterEnum("geofence.Containment", Containment_name, Containment_value)
+	proto.RegisterEnum("geofence.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("geofence.Status", Status_name, Status_value)
+	proto.RegisterType((*Point)(nil), "geofence.Point")
+	proto.RegisterType((*Filter)(nil), "geofence.Filter")
+	proto.RegisterMapType((map[string]string)(nil), "geofence.Filter.AttributesEntry")
+	proto.RegisterType((*UserPoints)(nil), "geofence.UserPoints")
+	proto.RegisterType((*Points)(nil), "geofence.Points")
+	proto.RegisterType((*PointWithGeofence)(nil), "geofence.PointWithGeofence")
+	proto.RegisterType((*NearestRequest)(nil), "geofence.NearestRequest")
+	proto.RegisterType((*BoundsRequest)(nil), "geofence.BoundsRequest")
+	proto.RegisterType((*Track)(nil), "geofence.Track")
+	proto.RegisterType((*PolygonRequest)(nil), "geofence.PolygonRequest")
+	proto.RegisterType((*GeofenceRequest)(nil), "geofence.GeofenceRequest")
+	proto.RegisterType((*DevicePoints)(nil), "geofence.DevicePoints")
+	proto.RegisterType((*WatchRequest)(nil), "geofence.WatchRequest")
+	proto.RegisterType((*QuarantineRequest)(nil), "geofence.QuarantineRequest")
+	proto.RegisterType((*DeadLettersRequest)(nil), "geofence.DeadLettersRequest")
+	proto.RegisterType((*GeofenceInfo)(nil), "geofence.GeofenceInfo")
+	proto.RegisterMapType((map[string]string)(nil), "geofence.GeofenceInfo.AttributesEntry")
+	proto.RegisterType((*Geofence)(nil), "geofence.Geofence")
+	proto.RegisterType((*Geofences)(nil), "geofence.Geofences")
+	proto.RegisterType((*GeofenceGeometry)(nil), "geofence.GeofenceGeometry")
+	proto.RegisterType((*GeofenceGeometries)(nil), "geofence.GeofenceGeometries")
+	proto.RegisterType((*TrackPoint)(nil), "geofence.TrackPoint")
+	proto.RegisterType((*Crossing)(nil), "geofence.Crossing")
+	proto.RegisterType((*TrackCrossings)(nil), "geofence.TrackCrossings")
+	proto.RegisterType((*PolygonRelation)(nil), "geofence.PolygonRelation")
+	proto.RegisterType((*PolygonRelations)(nil), "geofence.PolygonRelations")
+	proto.RegisterType((*Coordinates)(nil), "geofence.Coordinates")
+	proto.RegisterType((*BoundingBox)(nil), "geofence.BoundingBox")
+	proto.RegisterType((*PolygonDetails)(nil), "geofence.PolygonDetails")
+	proto.RegisterType((*GeofenceDetails)(nil), "geofence.GeofenceDetails")
+	proto.RegisterType((*DeviceEvent)(nil), "geofence.DeviceEvent")
+	proto.RegisterType((*DeviceEvents)(nil), "geofence.DeviceEvents")
+	proto.RegisterType((*PositionUpdate)(nil), "geofence.PositionUpdate")
+	proto.RegisterType((*GeofenceEvent)(nil), "geofence.GeofenceEvent")
+	proto.RegisterType((*GeometryIssue)(nil), "geofence.GeometryIssue")
+	proto.RegisterType((*QuarantinedGeofence)(nil), "geofence.QuarantinedGeofence")
+	proto.RegisterType((*Quarantine)(nil), "geofence.Quarantine")
+	proto.RegisterType((*DeadLetter)(nil), "geofence.DeadLetter")
+	proto.RegisterType((*DeadLetters)(nil), "geofence.DeadLetters")
+}
+
+func init() { proto.RegisterFile("geofences.proto", fileDescriptor_9b0d5848323ed639) }
+
+var fileDescriptor_9b0d5848323ed639 = []byte{
+	// 2570 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0x4b, 0x6f, 0x23, 0x59,
+	0xd5, 0x29, 0x97, 0x9f, 0xc7, 0x76, 0x52, 0x7d, 0x3b, 0x9d, 0x76, 0xbb, 0x67, 0xbe, 0x2f, 0xd4,
+	0x68, 0xa6, 0xa3, 0x0c, 0x1d, 0x9a, 0x30, 0x68, 0x5a, 0x33, 0x42, 0x9a, 0x3c, 0x9c, 0xb4, 0xbb,
+	0x83, 0x9d, 0xb9, 0x76, 0x26, 0x34, 0x2c, 0xac, 0x8a, 0xeb, 0x26, 0x29, 0x62, 0x57, 0x99, 0xaa,
+	0xeb, 0x74, 0x82, 0x84, 0x84, 0x60, 0x31, 0x82, 0x41, 0xb0, 0x61, 0x83, 0xd8, 0xb0, 0x80, 0x05,
+	0x0b, 0x76, 0xc3, 0x06, 0x89, 0x9f, 0xc0, 0x6f, 0xe0, 0x2f, 0xb0, 0x82, 0x2d, 0xba, 0xaf, 0x7a,
+	0xc5, 0x76, 0x32, 0x64, 0x1a, 0xb1, 0xf3, 0x79, 0xd4, 0xa9, 0xf3, 0xbe, 0xe7, 0xdc, 0x32, 0x2c,
+	0x9c, 0x10, 0xef, 0x98, 0xb8, 0x7d, 0x12, 0xac, 0x8d, 0x7c, 0x8f, 0x7a, 0xa8, 0xa8, 0x10, 0xe6,
+	0x67, 0x19, 0xc8, 0xed, 0x7b, 0x8e, 0x4b, 0xd1, 0x03, 0x28, 0x8e, 0xd8, 0x8f, 0x9e, 0x63, 0xd7,
+	0xb4, 0x65, 0x6d, 0x25, 0x8b, 0x0b, 0x1c, 0x6e, 0xda, 0xa8, 0x0e, 0xc5, 0x81, 0x45, 0x1d, 0x3a,
+	0xb6, 0x49, 0x2d, 0xb3, 0xac, 0xad, 0x68, 0x38, 0x84, 0xd1, 0x1b, 0x50, 0x1a, 0x78, 0xee, 0x89,
+	0x20, 0xea, 0x9c, 0x18, 0x21, 0xd8, 0x93, 0x56, 0xbf, 0x3f, 0xf6, 0xad, 0xfe, 0x65, 0x2d, 0x2b,
+	0x9e, 0x54, 0x30, 0x7b, 0x92, 0x3a, 0x43, 0x12, 0x50, 0x6b, 0x38, 0xaa, 0xe5, 0x96, 0xb5, 0x15,
+	0x1d, 0x47, 0x08, 0xfe, 0xe4, 0x40, 0xbe, 0x33, 0x2f, 0x9f, 0x94, 0x30, 0xfa, 0x0a, 0x54, 0x4e,
+	0xad, 0xa0, 0x17, 0xd2, 0x0b, 0xcb, 0xda, 0x4a, 0x11, 0x97, 0x4f, 0xad, 0x60, 0x43, 0xb1, 0x3c,
+	0x84, 0x92, 0x4d, 0xce, 0x9d, 0x3e, 0x61, 0xe6, 0x14, 0xb9, 0x39, 0x45, 0x81, 0x68, 0xda, 0xe8,
+	0x3e, 0x14, 0xc6, 0x01, 0xf1, 0x19, 0xa9, 0xc4, 0x49, 0x79, 0x06, 0x36, 0x6d, 0xf3, 0xaf, 0x1a,
+	0xe4, 0x77, 0x9c, 0x01, 0x25, 0x3e, 0xfa, 0x3f, 0x80, 0xbe, 0x45, 0xc9, 0x89, 0xe7, 0x3b, 0x24,
+	0xa8, 0x69, 0xcb, 0xfa, 0x4a, 0x09, 0xc7, 0x30, 0x08, 0x41, 0x96, 0x5a, 0x27, 0x41, 0x2d, 0xc3,
+	0x29, 0xfc, 0x37, 0xfa, 0x08, 0xc0, 0xa2, 0xd4, 0x77, 0x8e, 0xc6, 0x94, 0x04, 0x35, 0x7d, 0x59,
+	0x5f, 0x29, 0xaf, 0x2f, 0xaf, 0x29, 0x5f, 0xaf, 0x09, 0xc9, 0x6b, 0x1b, 0x21, 0x4b, 0xc3, 0xa5,
+	0xfe, 0x25, 0x8e, 0x3d, 0x53, 0xff, 0x16, 0x2c, 0xa4, 0xc8, 0xc8, 0x00, 0xfd, 0x8c, 0x5c, 0xf2,
+	0x90, 0x94, 0x30, 0xfb, 0x89, 0x16, 0x21, 0x77, 0x6e, 0x0d, 0xc6, 0x22, 0x16, 0x25, 0x2c, 0x80,
+	0x0f, 0x32, 0x4f, 0x35, 0xf3, 0xb7, 0x1a, 0xc0, 0x41, 0x40, 0x7c, 0x1e, 0xd1, 0x20, 0x6e, 0xa7,
+	0x16, 0xb7, 0x13, 0xbd, 0x05, 0xd5, 0x57, 0x0e, 0x3d, 0xed, 0xd9, 0x4e, 0x40, 0x2d, 0xb7, 0x2f,
+	0x24, 0x15, 0x71, 0x85, 0x21, 0xb7, 0x25, 0x0e, 0xbd, 0x0d, 0x39, 0x87, 0x92, 0xa1, 0x32, 0x64,
+	0x21, 0x32, 0x84, 0x8b, 0xc7, 0x82, 0x8a, 0x56, 0x20, 0x7f, 0xcc, 0x0d, 0xe3, 0x01, 0x2e, 0xaf,
+	0x1b, 0x69, 0x83, 0xb1, 0xa4, 0x9b, 0xbf, 0xd1, 0x20, 0x2f, 0x35, 0x7b, 0x04, 0x79, 0x9e, 0x5c,
+	0xc2, 0xb3, 0x13, 0x84, 0x4b, 0x32, 0xd3, 0x34, 0x20, 0x96, 0xdf, 0x3f, 0xed, 0xf9, 0x96, 0xed,
+	0x8c, 0x03, 0x99, 0x7f, 0x15, 0x81, 0xc4, 0x1c, 0x17, 0x53, 0x41, 0x9f, 0xad, 0x42, 0xdc, 0x23,
+	0xd9, 0x44, 0xe4, 0x3f, 0xd5, 0xe0, 0x0e, 0x7f, 0xf3, 0xa1, 0x43, 0x4f, 0x77, 0xe5, 0xd3, 0x37,
+	0x57, 0xf3, 0xff, 0xa1, 0xac, 0x28, 0x4c, 0x36, 0x4b, 0x8a, 0x2c, 0x06, 0x85, 0x6a, 0xda, 0x37,
+	0x57, 0xd1, 0xfc, 0xb3, 0x06, 0xf3, 0x2d, 0x62, 0xf9, 0x24, 0xa0, 0x98, 0xfc, 0x60, 0x4c, 0x02,
+	0x7a, 0x73, 0x35, 0x62, 0xe6, 0x65, 0x12, 0x01, 0x5f, 0x84, 0xdc, 0xc0, 0x19, 0x3a, 0x94, 0xbf,
+	0xbd, 0x8a, 0x05, 0xc0, 0xea, 0x68, 0x68, 0x5d, 0x44, 0x59, 0x20, 0x2a, 0xb4, 0x3c, 0xb4, 0x2e,
+	0xc2, 0x24, 0x88, 0xf4, 0xce, 0x5d, 0xa3, 0xf7, 0x3f, 0x35, 0xa8, 0x6e, 0x7a, 0x63, 0xd7, 0x0e,
+	0x94, 0xda, 0x4c, 0xbc, 0xe3, 0xf6, 0xc2, 0xd6, 0xa1, 0x49, 0xf1, 0x8e, 0xbb, 0x27, 0x51, 0x2c,
+	0xbc, 0x9c, 0x25, 0xec, 0x20, 0x32, 0xbc, 0x8c, 0x47, 0xe1, 0x94, 0x9a, 0xa1, 0x1c, 0x3d, 0x54,
+	0x33, 0x21, 0x87, 0xb1, 0x84, 0x72, 0xb2, 0x52, 0x8e, 0x75, 0x11, 0xc9, 0x89, 0x79, 0x27, 0x97,
+	0xf0, 0x0e, 0x82, 0xec, 0x0f, 0x3d, 0x6f, 0xc8, 0xfb, 0x4c, 0x15, 0xf3, 0xdf, 0x31, 0xc3, 0x0b,
+	0xd7, 0x18, 0x1e, 0x40, 0xae, 0xeb, 0x5b, 0xfd, 0xb3, 0x2f, 0x21, 0x4c, 0x37, 0xcf, 0x92, 0x5f,
+	0x69, 0x30, 0xbf, 0xef, 0x0d, 0x2e, 0x4f, 0x3c, 0x57, 0xb9, 0xbb, 0x0e, 0x85, 0x13, 0xe2, 0x7d,
+	0x3f, 0xf0, 0x5c, 0xd1, 0x2c, 0x9e, 0xcd, 0x61, 0x85, 0x40, 0x08, 0xf4, 0x57, 0x67, 0x47, 0xfc,
+	0x6d, 0x95, 0x67, 0x73, 0x98, 0x01, 0x71, 0x2d, 0xf4, 0x29, 0x5a, 0x5c, 0x53, 0xd1, 0x9b, 0x00,
+	0xec, 0x24, 0x19, 0x12, 0xea, 0x5f, 0x9a, 0x7f, 0xd2, 0x60, 0x41, 0x15, 0x8e, 0x52, 0x29, 0x55,
+	0x16, 0xa2, 0x09, 0xc5, 0xcb, 0xe2, 0x4d, 0x80, 0x91, 0xb0, 0x22, 0x72, 0x46, 0x49, 0x62, 0x9a,
+	0x36, 0x7a, 0x02, 0xf9, 0x63, 0xcf, 0x1f, 0x5a, 0x22, 0x6f, 0xe7, 0xd7, 0x6b, 0x91, 0x26, 0xbb,
+	0xf2, 0xbd, 0x3b, 0x9c, 0x8e, 0x25, 0xdf, 0x17, 0xe8, 0x46, 0xfb, 0x50, 0xd9, 0xe6, 0x07, 0xc2,
+	0x17, 0x6d, 0x49, 0xd3, 0xa2, 0x67, 0xfe, 0x5c, 0x83, 0xca, 0xa1, 0x45, 0xfb, 0xa7, 0xca, 0xfc,
+	0xb7, 0xa0, 0x7a, 0xec, 0x7b, 0xc3, 0x5e, 0xc0, 0x60, 0x56, 0x60, 0xc2, 0x01, 0x15, 0x86, 0xec,
+	0x48, 0x5c, 0x52, 0x9c, 0x1e, 0x0b, 0x43, 0xca, 0x79, 0xfa, 0x95, 0x9e, 0x92, 0x38, 0xe3, 0xb2,
+	0xcb, 0x7a, 0xfc, 0x8c, 0x33, 0xbf, 0x0a, 0x77, 0x3e, 0x1e, 0x5b, 0xbe, 0xe5, 0x52, 0xc7, 0x0d,
+	0xe3, 0x31, 0xed, 0x40, 0x30, 0x1f, 0x03, 0xda, 0x26, 0x96, 0xbd, 0x47, 0x28, 0x25, 0x7e, 0x70,
+	0x2d, 0xfb, 0xef, 0x73, 0x50, 0x51, 0xb1, 0x6e, 0xba, 0xc7, 0xde, 0xad, 0x03, 0xbd, 0x08, 0x39,
+	0xea, 0xd0, 0x81, 0xa8, 0xed, 0x12, 0x16, 0x00, 0x9b, 0x01, 0x52, 0xbd, 0x29, 0x84, 0xd1, 0xfb,
+	0x50, 0xee, 0x7b, 0x2e, 0xb5, 0x1c, 0x77, 0x48, 0x5c, 0xca, 0x0b, 0x7a, 0x7e, 0xfd, 0x5e, 0x14,
+	0xb3, 0xad, 0x88, 0x88, 0xe3, 0x9c, 0xe8, 0x6d, 0x98, 0xf7, 0xbd, 0x31, 0x25, 0x51, 0xdb, 0x13,
+	0xe3, 0x45, 0x95, 0x63, 0x63, 0xa7, 0x9f, 0x64, 0x1b, 0xf9, 0xde, 0x89, 0x4f, 0x82, 0xa0, 0x56,
+	0x88, 0xb1, 0xed, 0x4b, 0x24, 0x7a, 0x17, 0xee, 0x9c, 0x13, 0x9f, 0x3a, 0x7d, 0x6b, 0x10, 0x09,
+	0x2c, 0x72, 0x4e, 0x43, 0x11, 0x42, 0x99, 0x75, 0x28, 0xca, 0x09, 0xe2, 0x92, 0x0f, 0x1e, 0x25,
+	0x1c, 0xc2, 0xe1, 0x3c, 0x01, 0xb1, 0x79, 0x62, 0x27, 0x31, 0x4f, 0x94, 0x79, 0x5a, 0xbe, 0x93,
+	0x28, 0x81, 0x30, 0x02, 0xb3, 0xa6, 0x0a, 0xf4, 0x01, 0x54, 0x5d, 0x71, 0xa2, 0xf4, 0x78, 0x0e,
+	0xd7, 0x2a, 0xbc, 0x36, 0x12, 0xde, 0xf2, 0x7c, 0xdb, 0x71, 0x2d, 0x4a, 0x02, 0x5c, 0x91, 0xbc,
+	0x62, 0x2c, 0xac, 0x41, 0xe1, 0x88, 0x58, 0xbe, 0xe3, 0x9e, 0xd4, 0xaa, 0xdc, 0x2c, 0x05, 0xb2,
+	0xec, 0x0e, 0x43, 0xea, 0xda, 0xe4, 0xa2, 0x36, 0xcf, 0xdb, 0x67, 0x45, 0x45, 0x95, 0xe1, 0x58,
+	0xdc, 0x19, 0xb3, 0xe4, 0x58, 0xe0, 0x1c, 0x25, 0x86, 0x09, 0xc9, 0xc4, 0x3e, 0x21, 0x92, 0x6c,
+	0x08, 0x32, 0xc3, 0x70, 0xf2, 0x6d, 0xc7, 0xa1, 0x43, 0x28, 0x86, 0x47, 0xf9, 0x8c, 0xf1, 0xf6,
+	0x09, 0x6f, 0x9c, 0xcc, 0x8b, 0xbc, 0x02, 0xcb, 0xeb, 0x4b, 0x93, 0x7d, 0x8c, 0x15, 0x9b, 0xf9,
+	0x6b, 0x0d, 0x4a, 0x8a, 0x32, 0x63, 0xcc, 0x5a, 0x83, 0x70, 0xd0, 0x96, 0x92, 0xd1, 0x55, 0xc9,
+	0x38, 0xe4, 0x61, 0xcd, 0x2b, 0xa0, 0x16, 0x1d, 0x07, 0xb2, 0xdd, 0xc5, 0x9a, 0x57, 0x87, 0xe3,
+	0xb1, 0xa4, 0x33, 0x9b, 0x89, 0xef, 0x7b, 0xa2, 0xcb, 0x95, 0xb0, 0x00, 0xcc, 0x7f, 0x69, 0x60,
+	0x28, 0xb1, 0xaa, 0x3f, 0xbe, 0xa6, 0xd2, 0x9c, 0x36, 0x48, 0xa1, 0x55, 0xc8, 0x9e, 0x39, 0xae,
+	0x2d, 0x0b, 0x72, 0x82, 0x27, 0x5f, 0x38, 0xae, 0x8d, 0x39, 0x0f, 0xaa, 0x47, 0xc7, 0x07, 0x2f,
+	0xc2, 0x12, 0x0e, 0x61, 0xb4, 0x04, 0x79, 0x39, 0xf1, 0x89, 0xba, 0x93, 0x10, 0x53, 0xe7, 0x95,
+	0x63, 0xd3, 0x53, 0x59, 0x64, 0x02, 0x30, 0x7f, 0xa9, 0x01, 0x4a, 0x59, 0xce, 0x86, 0xf4, 0xa7,
+	0x50, 0x0a, 0x57, 0x1f, 0xd9, 0xd6, 0xeb, 0x57, 0x35, 0x52, 0xae, 0xc2, 0x11, 0x73, 0x2c, 0x14,
+	0x99, 0x9b, 0x86, 0x42, 0x8f, 0x87, 0xc2, 0x06, 0xe0, 0x43, 0x81, 0x28, 0xa2, 0xf8, 0x02, 0xa5,
+	0xcd, 0x5a, 0xa0, 0x32, 0xe9, 0x05, 0x2a, 0xb1, 0x24, 0xe9, 0xa9, 0x25, 0xc9, 0xfc, 0x71, 0x06,
+	0x8a, 0x5b, 0xbe, 0x17, 0x04, 0xac, 0x1e, 0x5f, 0x4f, 0xa0, 0x57, 0x21, 0x47, 0x58, 0xe1, 0xc9,
+	0xf3, 0x74, 0x31, 0xf2, 0x43, 0x64, 0x1f, 0x16, 0x2c, 0x68, 0x05, 0xb2, 0xe4, 0xc2, 0xa1, 0xb5,
+	0xdc, 0x0c, 0x56, 0xce, 0xc1, 0xba, 0x6b, 0x40, 0x2d, 0x9f, 0x12, 0xbb, 0xe7, 0xb8, 0x81, 0x23,
+	0x77, 0xbc, 0x22, 0xae, 0x4a, 0x6c, 0x93, 0x23, 0xd9, 0xe4, 0x47, 0x5c, 0x3b, 0x62, 0x92, 0x8b,
+	0x1e, 0xc7, 0x09, 0x16, 0xf3, 0x27, 0x1a, 0xcc, 0x73, 0xf1, 0xca, 0x0f, 0x01, 0x7a, 0x02, 0xa5,
+	0xbe, 0x02, 0x6a, 0x5a, 0xba, 0xee, 0x14, 0x1f, 0x8e, 0x98, 0x6e, 0x1d, 0xed, 0xbf, 0x68, 0xb0,
+	0x10, 0x4e, 0x63, 0x2c, 0xb2, 0x9e, 0xfb, 0x9a, 0xc2, 0xb1, 0x06, 0x45, 0x5f, 0xbe, 0x81, 0x47,
+	0x64, 0x3e, 0x6e, 0x9a, 0x7a, 0x37, 0x0e, 0x79, 0x98, 0x07, 0xbd, 0x73, 0xe2, 0x0f, 0xac, 0x51,
+	0xcf, 0xf2, 0x89, 0xc5, 0x43, 0xa3, 0xe1, 0xb2, 0xc4, 0x6d, 0xf8, 0xc4, 0x32, 0x3f, 0xd3, 0xc0,
+	0x48, 0x29, 0x1f, 0xa0, 0xf7, 0xa1, 0xa4, 0x64, 0x28, 0x1f, 0x3e, 0x88, 0x0f, 0x44, 0x09, 0x76,
+	0x1c, 0xf1, 0xde, 0xda, 0x95, 0xbb, 0x50, 0x8e, 0x1d, 0x46, 0xff, 0x79, 0xe5, 0x98, 0x3d, 0x28,
+	0xf3, 0x75, 0xc4, 0x71, 0x4f, 0x36, 0xbd, 0x0b, 0xf4, 0x08, 0xf4, 0xa1, 0x23, 0x26, 0xe3, 0xa9,
+	0x27, 0x1f, 0xe3, 0xe0, 0x8c, 0xd6, 0x45, 0x2d, 0x33, 0x9b, 0xd1, 0xba, 0x30, 0x3f, 0xd7, 0xc3,
+	0x11, 0x7c, 0x9b, 0x50, 0xcb, 0x19, 0x04, 0xff, 0xdb, 0xbd, 0x36, 0xdc, 0x0e, 0xf2, 0x53, 0xb6,
+	0x83, 0x42, 0x7c, 0x3b, 0x88, 0xfa, 0x6f, 0x71, 0x72, 0xff, 0x2d, 0xc5, 0xfa, 0x2f, 0x9b, 0x5e,
+	0x78, 0x7a, 0x01, 0x47, 0xf2, 0xdf, 0x2c, 0x3c, 0x23, 0xe2, 0x3b, 0x43, 0xc2, 0xa6, 0xf1, 0xb2,
+	0x08, 0x4f, 0x88, 0x40, 0x5f, 0x87, 0x62, 0x9f, 0xb8, 0xd4, 0xf7, 0x1c, 0x7b, 0xf6, 0x38, 0x12,
+	0xb2, 0xa1, 0xa7, 0x50, 0x39, 0x92, 0x11, 0xed, 0x1d, 0x79, 0x17, 0xb5, 0x6a, 0xfa, 0xb1, 0x58,
+	0xbc, 0x71, 0xf9, 0x28, 0x02, 0x12, 0x7b, 0xca, 0xa7, 0xb1, 0x3d, 0x45, 0xc5, 0xed, 0x3d, 0x28,
+	0xca, 0x20, 0xa8, 0x64, 0xaf, 0x5d, 0x49, 0x76, 0xc9, 0x8b, 0x43, 0xce, 0x5b, 0xa7, 0xfa, 0x3f,
+	0x34, 0x28, 0x8b, 0x15, 0xa4, 0x71, 0x4e, 0x5c, 0x9a, 0x9c, 0xe7, 0xb5, 0xd4, 0x9d, 0x55, 0x7c,
+	0x7e, 0xc9, 0x24, 0xe7, 0x97, 0x2b, 0x8b, 0xc2, 0xec, 0xac, 0xcb, 0x4e, 0xcd, 0xba, 0x5c, 0x3c,
+	0xeb, 0x1e, 0x41, 0x96, 0x5e, 0x8e, 0x44, 0x63, 0x9e, 0x5f, 0xbf, 0x1b, 0xd9, 0xc6, 0x95, 0xed,
+	0x5e, 0x8e, 0x08, 0xe6, 0x0c, 0xec, 0x84, 0x10, 0x53, 0x65, 0x61, 0xd6, 0x09, 0xc1, 0x59, 0xcc,
+	0xdf, 0x69, 0x6a, 0xeb, 0xe2, 0x52, 0x02, 0xf4, 0x18, 0xf2, 0x84, 0xff, 0x92, 0x7e, 0x8f, 0x45,
+	0x33, 0xc6, 0x87, 0x25, 0x13, 0x6b, 0x67, 0x01, 0xb5, 0x06, 0xa4, 0x27, 0x57, 0x35, 0xb1, 0x31,
+	0x95, 0x39, 0x4e, 0xee, 0x71, 0xb7, 0x1d, 0xa2, 0x7e, 0x9a, 0x61, 0x65, 0x1d, 0x38, 0xac, 0x9d,
+	0x1d, 0x8c, 0x6c, 0x8b, 0xce, 0x9c, 0x1d, 0x13, 0x31, 0xcb, 0xa4, 0x62, 0xf6, 0x5e, 0x7c, 0xfc,
+	0xd0, 0x67, 0x8e, 0x96, 0x11, 0x63, 0xcc, 0x25, 0xd9, 0x9b, 0xb8, 0x64, 0x11, 0x72, 0xdc, 0x7c,
+	0x1e, 0xbd, 0x22, 0x16, 0x40, 0xcc, 0x0b, 0xf9, 0x9b, 0x7a, 0xa1, 0x10, 0xf7, 0xc2, 0x2f, 0x32,
+	0x50, 0x55, 0x0a, 0x8a, 0xec, 0xac, 0x43, 0x31, 0xb5, 0xc7, 0x86, 0xf0, 0x6c, 0x2f, 0x4c, 0xbd,
+	0x67, 0x48, 0xe5, 0x6d, 0xf6, 0x9a, 0xbc, 0xcd, 0x4d, 0xcd, 0xdb, 0xfc, 0xa4, 0xbc, 0x2d, 0xdc,
+	0x38, 0x6f, 0x8b, 0xd7, 0xe7, 0xed, 0xe7, 0x1a, 0x77, 0x07, 0xef, 0x20, 0xcd, 0x20, 0x18, 0x13,
+	0xd6, 0x1f, 0x6d, 0x72, 0x4c, 0xfa, 0x54, 0xae, 0x22, 0x12, 0xba, 0xba, 0x15, 0x65, 0xae, 0xdd,
+	0x8a, 0xf4, 0xf4, 0x56, 0xf4, 0xae, 0xd2, 0x2c, 0x3b, 0xab, 0x31, 0x0a, 0x1e, 0x16, 0x17, 0x9f,
+	0x8c, 0x2c, 0xc7, 0x27, 0xb6, 0x4c, 0x81, 0x10, 0x36, 0xff, 0xa6, 0xc1, 0xdd, 0xe8, 0x16, 0xc0,
+	0x0e, 0x97, 0xa1, 0xff, 0xf2, 0x39, 0xc5, 0xce, 0x12, 0x62, 0xb1, 0xa3, 0x47, 0x74, 0x18, 0x09,
+	0xa1, 0xaf, 0x41, 0xde, 0x61, 0xce, 0x64, 0x49, 0xca, 0x32, 0xfd, 0xfe, 0xd5, 0xeb, 0x1d, 0xee,
+	0x6c, 0x2c, 0xd9, 0xcc, 0x9f, 0x69, 0x00, 0x91, 0x3d, 0xe8, 0xc3, 0xab, 0xe3, 0xfd, 0x9b, 0x91,
+	0x88, 0x09, 0x86, 0x7f, 0x99, 0x13, 0xfe, 0xdf, 0x35, 0x80, 0xe8, 0xce, 0x84, 0xb9, 0xd4, 0x26,
+	0x03, 0xe7, 0x9c, 0xf8, 0x97, 0x31, 0x97, 0x2a, 0x54, 0xb2, 0x0c, 0x92, 0x97, 0x7e, 0x06, 0xe8,
+	0x63, 0x7f, 0x20, 0x85, 0xb3, 0x9f, 0xe8, 0x31, 0xe4, 0x78, 0x71, 0xcb, 0xf8, 0xdf, 0xbf, 0xda,
+	0x33, 0x44, 0x0b, 0x10, 0x5c, 0xfc, 0x53, 0x09, 0xa5, 0x64, 0x38, 0xa2, 0x01, 0x77, 0x70, 0x15,
+	0x87, 0x30, 0x0b, 0xe4, 0xc0, 0x0a, 0x68, 0x4f, 0x18, 0x20, 0x0a, 0xa5, 0xc4, 0x30, 0x0d, 0x86,
+	0x60, 0x85, 0x7b, 0x6c, 0x39, 0x03, 0x62, 0xf7, 0x2c, 0xd1, 0xbf, 0x75, 0x5c, 0x14, 0x88, 0x0d,
+	0x6a, 0xfe, 0x08, 0xca, 0x91, 0x81, 0x01, 0x5a, 0x83, 0xc2, 0x40, 0xfc, 0x94, 0xbe, 0x5e, 0x8c,
+	0x37, 0x26, 0xc5, 0x87, 0x15, 0xd3, 0x6d, 0x1d, 0xbc, 0xfa, 0x0e, 0xcc, 0x27, 0x2f, 0xf9, 0x50,
+	0x19, 0x0a, 0xbb, 0x8d, 0xf6, 0xf3, 0x4e, 0xbb, 0x65, 0xcc, 0xa1, 0x02, 0xe8, 0x87, 0x2f, 0x36,
+	0x0d, 0x6d, 0xb5, 0x05, 0xc5, 0x70, 0xe8, 0xae, 0xc1, 0x22, 0x6e, 0xec, 0x6d, 0x74, 0x9b, 0xed,
+	0x56, 0xef, 0xa0, 0xd5, 0xd9, 0x6f, 0x6c, 0x35, 0x77, 0x9a, 0x8d, 0x6d, 0x63, 0x0e, 0xcd, 0x03,
+	0x34, 0x5b, 0xdd, 0x06, 0xee, 0x34, 0xb6, 0xba, 0x1d, 0x43, 0x43, 0x15, 0x28, 0x6e, 0xb5, 0x5b,
+	0xdd, 0x8d, 0x66, 0xab, 0x63, 0x64, 0x10, 0x40, 0xfe, 0xb0, 0xd9, 0x7d, 0xd6, 0x6c, 0x19, 0xfa,
+	0xea, 0x37, 0xa1, 0x12, 0x9f, 0x9f, 0xd8, 0x5b, 0xf7, 0xdb, 0x7b, 0x2f, 0x77, 0xf9, 0x5b, 0x01,
+	0xf2, 0x5b, 0x4d, 0xbc, 0xb5, 0xd7, 0x50, 0x22, 0x30, 0x6e, 0x6e, 0xb7, 0xb1, 0x91, 0x59, 0xfd,
+	0x1e, 0x1b, 0x5c, 0xa3, 0x6b, 0xa6, 0x87, 0x70, 0x5f, 0xca, 0xff, 0x76, 0xa3, 0xd5, 0x4d, 0x29,
+	0x03, 0x90, 0x6f, 0xb6, 0x3a, 0xcd, 0x6d, 0x26, 0xa5, 0x0c, 0x85, 0xf6, 0x41, 0x97, 0x03, 0x19,
+	0xb4, 0x04, 0x68, 0xb3, 0x7d, 0xd0, 0xda, 0xde, 0xc0, 0x2f, 0x7b, 0x07, 0xad, 0xad, 0x06, 0x66,
+	0x02, 0x0c, 0x7d, 0xf5, 0x0c, 0x4a, 0x61, 0xfb, 0x42, 0x75, 0x58, 0x6a, 0x7c, 0xc2, 0x84, 0x76,
+	0x5f, 0xee, 0x37, 0x52, 0x92, 0x17, 0xa0, 0x2c, 0x68, 0x0d, 0x66, 0xac, 0xa1, 0x31, 0xbb, 0x25,
+	0xe2, 0x3b, 0xcd, 0xae, 0x91, 0x41, 0x06, 0x54, 0x04, 0x2c, 0x15, 0xd0, 0xa3, 0x47, 0xb6, 0x0f,
+	0x1b, 0x7b, 0x7b, 0x46, 0x76, 0xf5, 0x05, 0xe4, 0x45, 0x80, 0x50, 0x1e, 0x32, 0xed, 0x17, 0xc6,
+	0x1c, 0xaa, 0x42, 0xa9, 0xd5, 0xee, 0xf6, 0x76, 0x98, 0x6a, 0x86, 0xc6, 0x9e, 0xd8, 0xdc, 0xd8,
+	0xee, 0xe1, 0xc6, 0xc7, 0x07, 0x8d, 0x0e, 0x13, 0xfa, 0x00, 0xee, 0x71, 0xe7, 0xb6, 0x36, 0xf6,
+	0x7a, 0x9d, 0x06, 0xfe, 0xa4, 0x81, 0x7b, 0x0d, 0x8c, 0xdb, 0xd8, 0xd0, 0xd7, 0xff, 0x98, 0x8f,
+	0xc6, 0xad, 0x0e, 0xf1, 0xd9, 0x99, 0x80, 0xb6, 0x60, 0x71, 0x97, 0x50, 0x85, 0x0d, 0x36, 0x2f,
+	0x0f, 0xe4, 0x57, 0x8a, 0x28, 0x43, 0xa2, 0xaf, 0x58, 0xf5, 0xbb, 0x57, 0xd3, 0x3f, 0x30, 0xe7,
+	0xd0, 0x73, 0x58, 0xdc, 0x3a, 0x25, 0xfd, 0x33, 0x85, 0xdb, 0xbc, 0xe4, 0xfc, 0xe8, 0x61, 0xea,
+	0xde, 0x36, 0xfe, 0x41, 0x67, 0x9a, 0xac, 0x8f, 0xe0, 0xde, 0x2e, 0xa1, 0xea, 0x9e, 0xae, 0xeb,
+	0x29, 0x1a, 0x32, 0x52, 0xc2, 0xa6, 0x6a, 0xb3, 0x03, 0x77, 0x77, 0x09, 0x95, 0xdf, 0x6d, 0x42,
+	0x02, 0x8a, 0x8d, 0x91, 0xc9, 0x6f, 0x3a, 0xd3, 0xe4, 0xb4, 0x93, 0xae, 0x69, 0xba, 0xe2, 0x93,
+	0x0a, 0xba, 0x9f, 0x9a, 0x72, 0xd5, 0x1d, 0x6d, 0xfd, 0x8d, 0xa9, 0xf7, 0x19, 0x8e, 0x34, 0xed,
+	0xce, 0x2e, 0xa1, 0xa9, 0x0d, 0x79, 0x21, 0x75, 0xd6, 0xd5, 0x6b, 0x29, 0x44, 0xc8, 0xca, 0x55,
+	0xba, 0x97, 0x8c, 0x96, 0x1c, 0x88, 0x51, 0x6d, 0xc2, 0x42, 0x28, 0x94, 0xaa, 0x4f, 0x5d, 0x15,
+	0x99, 0xc0, 0x06, 0x94, 0x63, 0x02, 0xd1, 0x83, 0xab, 0x16, 0x28, 0x39, 0x13, 0x48, 0x72, 0x0c,
+	0xe7, 0x96, 0x55, 0xb8, 0xae, 0x62, 0x28, 0x0a, 0xd0, 0x52, 0x7a, 0x4e, 0x92, 0x11, 0x5b, 0x9a,
+	0x38, 0x3f, 0x31, 0x09, 0x9b, 0xb0, 0xd0, 0xa1, 0x3e, 0xb1, 0x86, 0x6a, 0xde, 0x4b, 0x78, 0x86,
+	0x3f, 0x5e, 0x4f, 0x18, 0x19, 0x9f, 0x0a, 0xcd, 0xb9, 0x15, 0xed, 0x89, 0x86, 0x9e, 0xc3, 0x5d,
+	0x7e, 0xe7, 0x9f, 0xe8, 0xcc, 0x09, 0x65, 0xe2, 0x9f, 0x04, 0xea, 0xd3, 0x7a, 0xb9, 0x39, 0xf7,
+	0x44, 0x5b, 0xff, 0x83, 0x06, 0x8b, 0x0a, 0xbb, 0x61, 0x0f, 0x1d, 0x57, 0x15, 0xcc, 0x36, 0x9b,
+	0x3e, 0x68, 0xec, 0xe4, 0x7b, 0x38, 0xe9, 0x98, 0x53, 0xef, 0x58, 0x9c, 0x44, 0x34, 0xe7, 0xd0,
+	0x2e, 0x6b, 0xa8, 0x34, 0xde, 0xd2, 0xdf, 0x98, 0xd4, 0xc1, 0xc3, 0xd4, 0xba, 0x37, 0x91, 0x6a,
+	0xce, 0x6d, 0x56, 0xbe, 0x0b, 0x6b, 0x1f, 0x2a, 0xda, 0x51, 0x9e, 0xff, 0xa7, 0xe0, 0x1b, 0xff,
+	0x1e, 0x00, 0x17, 0x24, 0xe5, 0x56, 0x66, 0x20, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GeofenceServiceClient is the client API for GeofenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GeofenceServiceClient interface {
+	GetGeofencesByUserId(ctx context.Context, in *UserPoints, opts ...grpc.CallOption) (*Geofences, error)
+	CheckGeofenceByPoint(ctx context.Context, in *PointWithGeofence, opts ...grpc.CallOption) (*Geofences, error)
+	GetDistanceToGeofence(ctx context.Context, in *Points, opts ...grpc.CallOption) (*Geofences, error)
+	GetNearestGeofences(ctx context.Context, in *NearestRequest, opts ...grpc.CallOption) (*Geofences, error)
+	GetGeofencesInBounds(ctx context.Context, in *BoundsRequest, opts ...grpc.CallOption) (*GeofenceGeometries, error)
+	GetTrackCrossings(ctx context.Context, in *Track, opts ...grpc.CallOption) (*TrackCrossings, error)
+	GetGeofencesByPolygon(ctx context.Context, in *PolygonRequest, opts ...grpc.CallOption) (*PolygonRelations, error)
+	GetGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*GeofenceDetails, error)
+	TrackDevices(ctx context.Context, in *DevicePoints, opts ...grpc.CallOption) (*DeviceEvents, error)
+	StreamPositions(ctx context.Context, opts ...grpc.CallOption) (GeofenceService_StreamPositionsClient, error)
+	WatchGeofenceEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GeofenceService_WatchGeofenceEventsClient, error)
+}
+
+type geofenceServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGeofenceServiceClient(cc *grpc.ClientConn) GeofenceServiceClient {
+	return &geofenceServiceClient{cc}
+}
+
+func (c *geofenceServiceClient) GetGeofencesByUserId(ctx context.Context, in *UserPoints, opts ...grpc.CallOption) (*Geofences, error) {
+	out := new(Geofences)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetGeofencesByUserId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) CheckGeofenceByPoint(ctx context.Context, in *PointWithGeofence, opts ...grpc.CallOption) (*Geofences, error) {
+	out := new(Geofences)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/CheckGeofenceByPoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetDistanceToGeofence(ctx context.Context, in *Points, opts ...grpc.CallOption) (*Geofences, error) {
+	out := new(Geofences)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetDistanceToGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetNearestGeofences(ctx context.Context, in *NearestRequest, opts ...grpc.CallOption) (*Geofences, error) {
+	out := new(Geofences)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetNearestGeofences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetGeofencesInBounds(ctx context.Context, in *BoundsRequest, opts ...grpc.CallOption) (*GeofenceGeometries, error) {
+	out := new(GeofenceGeometries)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetGeofencesInBounds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetTrackCrossings(ctx context.Context, in *Track, opts ...grpc.CallOption) (*TrackCrossings, error) {
+	out := new(TrackCrossings)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetTrackCrossings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetGeofencesByPolygon(ctx context.Context, in *PolygonRequest, opts ...grpc.CallOption) (*PolygonRelations, error) {
+	out := new(PolygonRelations)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetGeofencesByPolygon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*GeofenceDetails, error) {
+	out := new(GeofenceDetails)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return out, nil
 }
 
-func init() {
-	proto.RegisterEnum("geofence.Status", Status_name, Status_value)
-	proto.RegisterType((*Point)(nil), "geofence.Point")
-	proto.RegisterType((*UserPoints)(nil), "geofence.UserPoints")
-	proto.RegisterType((*Points)(nil), "geofence.Points")
-	proto.RegisterType((*PointWithGeofence)(nil), "geofence.PointWithGeofence")
-	proto.RegisterType((*GeofenceInfo)(nil), "geofence.GeofenceInfo")
-	proto.RegisterType((*Geofence)(nil), "geofence.Geofence")
-	proto.RegisterType((*Geofences)(nil), "geofence.Geofences")
+func (c *geofenceServiceClient) TrackDevices(ctx context.Context, in *DevicePoints, opts ...grpc.CallOption) (*DeviceEvents, error) {
+	out := new(DeviceEvents)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/TrackDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func init() { proto.RegisterFile("geofences.proto", fileDescriptor_9b0d5848323ed639) }
-
-var fileDescriptor_9b0d5848323ed639 = []byte{
-	// 541 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5f, 0x8f, 0xd2, 0x4e,
-	0x14, 0xdd, 0xf2, 0xa7, 0xd0, 0x0b, 0xfb, 0xa3, 0xbf, 0x91, 0x55, 0x76, 0xd5, 0x48, 0x6a, 0x8c,
-	0xc4, 0x87, 0x46, 0xf1, 0xd1, 0x17, 0x97, 0xa5, 0x92, 0xba, 0x06, 0x74, 0x00, 0x37, 0x31, 0x31,
-	0x4d, 0x6d, 0x67, 0xa1, 0x11, 0x3b, 0xd8, 0x19, 0x54, 0xde, 0xfc, 0x10, 0x7e, 0x44, 0x3f, 0x88,
-	0xe9, 0x4c, 0xa7, 0x25, 0xac, 0xbb, 0xf1, 0xad, 0xf7, 0x9c, 0x7b, 0xcf, 0xdc, 0x33, 0xf7, 0x4e,
-	0xa1, 0xb5, 0x20, 0xf4, 0x92, 0xc4, 0x01, 0x61, 0xf6, 0x3a, 0xa1, 0x9c, 0xa2, 0xba, 0x02, 0xac,
-	0x1f, 0x50, 0x7d, 0x4b, 0xa3, 0x98, 0xa3, 0x63, 0xa8, 0xaf, 0xd3, 0x0f, 0x2f, 0x0a, 0x3b, 0x5a,
-	0x57, 0xeb, 0x55, 0x70, 0x4d, 0xc4, 0x6e, 0x88, 0x4e, 0xa0, 0xbe, 0xf2, 0x79, 0xc4, 0x37, 0x21,
-	0xe9, 0x94, 0xba, 0x5a, 0x4f, 0xc3, 0x79, 0x8c, 0xee, 0x81, 0xb1, 0xa2, 0xf1, 0x42, 0x92, 0x65,
-	0x41, 0x16, 0x40, 0x5a, 0xe9, 0x07, 0xc1, 0x26, 0xf1, 0x83, 0x6d, 0xa7, 0x22, 0x2b, 0x55, 0x6c,
-	0x7d, 0x05, 0x98, 0x33, 0x92, 0x88, 0xd3, 0x19, 0xba, 0x03, 0xb5, 0x0d, 0x23, 0x49, 0x71, 0xba,
-	0x9e, 0x86, 0x6e, 0x88, 0x1e, 0xc2, 0xe1, 0xf7, 0x88, 0x2f, 0xbd, 0x30, 0x62, 0xdc, 0x8f, 0x03,
-	0xd9, 0x41, 0x1d, 0x37, 0x53, 0x70, 0x98, 0x61, 0xe8, 0x11, 0x54, 0x23, 0x4e, 0xbe, 0xb0, 0x4e,
-	0xb9, 0x5b, 0xee, 0x35, 0xfa, 0x2d, 0x5b, 0xf9, 0xb3, 0x85, 0x3c, 0x96, 0xac, 0xf5, 0x0c, 0xf4,
-	0xec, 0xb8, 0xc7, 0xa0, 0x0b, 0x77, 0xac, 0xa3, 0xfd, 0xbd, 0x22, 0xa3, 0xad, 0x8f, 0xf0, 0xbf,
-	0x00, 0x2e, 0x22, 0xbe, 0x1c, 0x65, 0x29, 0xff, 0x5c, 0x8d, 0x1e, 0x40, 0x43, 0x31, 0xa9, 0xb3,
-	0x52, 0xb7, 0xdc, 0xab, 0x60, 0x50, 0x90, 0x1b, 0x5a, 0x3f, 0x35, 0x68, 0x2a, 0x59, 0x37, 0xbe,
-	0xa4, 0xfb, 0x15, 0xf2, 0x2e, 0x76, 0x2a, 0xd0, 0x7d, 0x80, 0x35, 0x5d, 0x6d, 0x17, 0x34, 0x96,
-	0x8a, 0x29, 0x6f, 0x64, 0x88, 0x1b, 0xa2, 0x36, 0x54, 0x79, 0xc4, 0x57, 0x72, 0x16, 0x06, 0x96,
-	0x41, 0x3a, 0x87, 0xfc, 0xfe, 0xb2, 0x39, 0xa8, 0xd8, 0xba, 0x80, 0x7a, 0x6e, 0xec, 0x86, 0x25,
-	0x78, 0x0a, 0xb5, 0x05, 0xa1, 0x69, 0x8f, 0xc2, 0x46, 0xa3, 0x7f, 0xbb, 0x30, 0xbd, 0xeb, 0x00,
-	0xab, 0x34, 0xeb, 0x97, 0x06, 0x86, 0x62, 0x6e, 0x18, 0xb0, 0x0d, 0xf9, 0x36, 0x66, 0xca, 0xe8,
-	0xaa, 0x32, 0xce, 0x73, 0x50, 0x0f, 0x74, 0xc6, 0x7d, 0xbe, 0x61, 0xc2, 0xe2, 0x7f, 0x7d, 0xb3,
-	0xc8, 0x9e, 0x0a, 0x1c, 0x67, 0x7c, 0x7a, 0x17, 0x24, 0x49, 0x68, 0x22, 0x2c, 0x1b, 0x58, 0x06,
-	0x4f, 0xce, 0x41, 0x97, 0x79, 0x48, 0x87, 0xd2, 0xe4, 0xdc, 0x3c, 0x40, 0x87, 0x60, 0x8c, 0x27,
-	0x33, 0xef, 0xd5, 0x64, 0x3e, 0x1e, 0x9a, 0x1a, 0x6a, 0x41, 0x63, 0x70, 0x3a, 0xf4, 0xb0, 0xf3,
-	0x6e, 0xee, 0x4c, 0x67, 0x66, 0x09, 0x1d, 0xc3, 0x91, 0x3b, 0x9e, 0x39, 0x78, 0x7c, 0xfa, 0xc6,
-	0x9b, 0x3a, 0xf8, 0xbd, 0x83, 0x3d, 0x07, 0xe3, 0x09, 0x36, 0xcb, 0xfd, 0xdf, 0x1a, 0xb4, 0x54,
-	0x8f, 0x53, 0x92, 0x7c, 0x8b, 0x02, 0x82, 0xce, 0xa0, 0x3d, 0x22, 0x3c, 0x77, 0x3e, 0xd8, 0xce,
-	0xa5, 0xd1, 0x76, 0xd1, 0x68, 0xb1, 0xf8, 0x27, 0xb7, 0xae, 0x9a, 0x65, 0xd6, 0x01, 0x7a, 0x0d,
-	0xed, 0xb3, 0x25, 0x09, 0x3e, 0x2b, 0x6c, 0xb0, 0x95, 0xcf, 0xf4, 0xee, 0xde, 0xaa, 0xed, 0xee,
-	0xe5, 0x75, 0x5a, 0x2f, 0xe1, 0x68, 0x44, 0xb8, 0x7a, 0x2c, 0x33, 0x9a, 0x8f, 0xdb, 0xdc, 0x13,
-	0xbb, 0xae, 0x9b, 0x41, 0xf3, 0x03, 0xd8, 0x2f, 0x14, 0xf3, 0x49, 0x17, 0x3f, 0x91, 0xe7, 0x7f,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x3d, 0x02, 0xad, 0x1d, 0x57, 0x04, 0x00, 0x00,
+func (c *geofenceServiceClient) StreamPositions(ctx context.Context, opts ...grpc.CallOption) (GeofenceService_StreamPositionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GeofenceService_serviceDesc.Streams[0], "/geofence.GeofenceService/StreamPositions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geofenceServiceStreamPositionsClient{stream}
+	return x, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// GeofenceServiceClient is the client API for GeofenceService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GeofenceServiceClient interface {
-	GetGeofencesByUserId(ctx context.Context, in *UserPoints, opts ...grpc.CallOption) (*Geofences, error)
-	CheckGeofenceByPoint(ctx context.Context, in *PointWithGeofence, opts ...grpc.CallOption) (*Geofences, error)
-	GetDistanceToGeofence(ctx context.Context, in *Points, opts ...grpc.CallOption) (*Geofences, error)
+type GeofenceService_StreamPositionsClient interface {
+	Send(*Point) error
+	Recv() (*PositionUpdate, error)
+	grpc.ClientStream
 }
 
-type geofenceServiceClient struct {
-	cc *grpc.ClientConn
+type geofenceServiceStreamPositionsClient struct {
+	grpc.ClientStream
 }
 
-func NewGeofenceServiceClient(cc *grpc.ClientConn) GeofenceServiceClient {
-	return &geofenceServiceClient{cc}
+func (x *geofenceServiceStreamPositionsClient) Send(m *Point) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func (c *geofenceServiceClient) GetGeofencesByUserId(ctx context.Context, in *UserPoints, opts ...grpc.CallOption) (*Geofences, error) {
-	out := new(Geofences)
-	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetGeofencesByUserId", in, out, opts...)
-	if err != nil {
+func (x *geofenceServiceStreamPositionsClient) Recv() (*PositionUpdate, error) {
+	m := new(PositionUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *geofenceServiceClient) CheckGeofenceByPoint(ctx context.Context, in *PointWithGeofence, opts ...grpc.CallOption) (*Geofences, error) {
-	out := new(Geofences)
-	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/CheckGeofenceByPoint", in, out, opts...)
+func (c *geofenceServiceClient) WatchGeofenceEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GeofenceService_WatchGeofenceEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GeofenceService_serviceDesc.Streams[1], "/geofence.GeofenceService/WatchGeofenceEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &geofenceServiceWatchGeofenceEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *geofenceServiceClient) GetDistanceToGeofence(ctx context.Context, in *Points, opts ...grpc.CallOption) (*Geofences, error) {
-	out := new(Geofences)
-	err := c.cc.Invoke(ctx, "/geofence.GeofenceService/GetDistanceToGeofence", in, out, opts...)
-	if err != nil {
+type GeofenceService_WatchGeofenceEventsClient interface {
+	Recv() (*GeofenceEvent, error)
+	grpc.ClientStream
+}
+
+type geofenceServiceWatchGeofenceEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *geofenceServiceWatchGeofenceEventsClient) Recv() (*GeofenceEvent, error) {
+	m := new(GeofenceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 // GeofenceServiceServer is the server API for GeofenceService service.
@@ -542,6 +3250,14 @@ type GeofenceServiceServer interface {
 	GetGeofencesByUserId(context.Context, *UserPoints) (*Geofences, error)
 	CheckGeofenceByPoint(context.Context, *PointWithGeofence) (*Geofences, error)
 	GetDistanceToGeofence(context.Context, *Points) (*Geofences, error)
+	GetNearestGeofences(context.Context, *NearestRequest) (*Geofences, error)
+	GetGeofencesInBounds(context.Context, *BoundsRequest) (*GeofenceGeometries, error)
+	GetTrackCrossings(context.Context, *Track) (*TrackCrossings, error)
+	GetGeofencesByPolygon(context.Context, *PolygonRequest) (*PolygonRelations, error)
+	GetGeofence(context.Context, *GeofenceRequest) (*GeofenceDetails, error)
+	TrackDevices(context.Context, *DevicePoints) (*DeviceEvents, error)
+	StreamPositions(GeofenceService_StreamPositionsServer) error
+	WatchGeofenceEvents(*WatchRequest, GeofenceService_WatchGeofenceEventsServer) error
 }
 
 // UnimplementedGeofenceServiceServer can be embedded to have forward compatible implementations.
@@ -557,6 +3273,30 @@ func (*UnimplementedGeofenceServiceServer) CheckGeofenceByPoint(ctx context.Cont
 func (*UnimplementedGeofenceServiceServer) GetDistanceToGeofence(ctx context.Context, req *Points) (*Geofences, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDistanceToGeofence not implemented")
 }
+func (*UnimplementedGeofenceServiceServer) GetNearestGeofences(ctx context.Context, req *NearestRequest) (*Geofences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearestGeofences not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) GetGeofencesInBounds(ctx context.Context, req *BoundsRequest) (*GeofenceGeometries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeofencesInBounds not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) GetTrackCrossings(ctx context.Context, req *Track) (*TrackCrossings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrackCrossings not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) GetGeofencesByPolygon(ctx context.Context, req *PolygonRequest) (*PolygonRelations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeofencesByPolygon not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) GetGeofence(ctx context.Context, req *GeofenceRequest) (*GeofenceDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeofence not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) TrackDevices(ctx context.Context, req *DevicePoints) (*DeviceEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrackDevices not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) StreamPositions(srv GeofenceService_StreamPositionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPositions not implemented")
+}
+func (*UnimplementedGeofenceServiceServer) WatchGeofenceEvents(req *WatchRequest, srv GeofenceService_WatchGeofenceEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGeofenceEvents not implemented")
+}
 
 func RegisterGeofenceServiceServer(s *grpc.Server, srv GeofenceServiceServer) {
 	s.RegisterService(&_GeofenceService_serviceDesc, srv)
@@ -616,6 +3356,161 @@ func _GeofenceService_GetDistanceToGeofence_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeofenceService_GetNearestGeofences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetNearestGeofences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/GetNearestGeofences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetNearestGeofences(ctx, req.(*NearestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_GetGeofencesInBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetGeofencesInBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/GetGeofencesInBounds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetGeofencesInBounds(ctx, req.(*BoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_GetTrackCrossings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Track)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetTrackCrossings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/GetTrackCrossings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetTrackCrossings(ctx, req.(*Track))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_GetGeofencesByPolygon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolygonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetGeofencesByPolygon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/GetGeofencesByPolygon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetGeofencesByPolygon(ctx, req.(*PolygonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_GetGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/GetGeofence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetGeofence(ctx, req.(*GeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_TrackDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DevicePoints)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).TrackDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceService/TrackDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).TrackDevices(ctx, req.(*DevicePoints))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_StreamPositions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GeofenceServiceServer).StreamPositions(&geofenceServiceStreamPositionsServer{stream})
+}
+
+type GeofenceService_StreamPositionsServer interface {
+	Send(*PositionUpdate) error
+	Recv() (*Point, error)
+	grpc.ServerStream
+}
+
+type geofenceServiceStreamPositionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *geofenceServiceStreamPositionsServer) Send(m *PositionUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *geofenceServiceStreamPositionsServer) Recv() (*Point, error) {
+	m := new(Point)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GeofenceService_WatchGeofenceEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeofenceServiceServer).WatchGeofenceEvents(m, &geofenceServiceWatchGeofenceEventsServer{stream})
+}
+
+type GeofenceService_WatchGeofenceEventsServer interface {
+	Send(*GeofenceEvent) error
+	grpc.ServerStream
+}
+
+type geofenceServiceWatchGeofenceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *geofenceServiceWatchGeofenceEventsServer) Send(m *GeofenceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _GeofenceService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "geofence.GeofenceService",
 	HandlerType: (*GeofenceServiceServer)(nil),
@@ -632,6 +3527,150 @@ var _GeofenceService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDistanceToGeofence",
 			Handler:    _GeofenceService_GetDistanceToGeofence_Handler,
 		},
+		{
+			MethodName: "GetNearestGeofences",
+			Handler:    _GeofenceService_GetNearestGeofences_Handler,
+		},
+		{
+			MethodName: "GetGeofencesInBounds",
+			Handler:    _GeofenceService_GetGeofencesInBounds_Handler,
+		},
+		{
+			MethodName: "GetTrackCrossings",
+			Handler:    _GeofenceService_GetTrackCrossings_Handler,
+		},
+		{
+			MethodName: "GetGeofencesByPolygon",
+			Handler:    _GeofenceService_GetGeofencesByPolygon_Handler,
+		},
+		{
+			MethodName: "GetGeofence",
+			Handler:    _GeofenceService_GetGeofence_Handler,
+		},
+		{
+			MethodName: "TrackDevices",
+			Handler:    _GeofenceService_TrackDevices_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPositions",
+			Handler:       _GeofenceService_StreamPositions_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchGeofenceEvents",
+			Handler:       _GeofenceService_WatchGeofenceEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "geofences.proto",
+}
+
+// GeofenceAdminServiceClient is the client API for GeofenceAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GeofenceAdminServiceClient interface {
+	GetQuarantine(ctx context.Context, in *QuarantineRequest, opts ...grpc.CallOption) (*Quarantine, error)
+	GetDeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...grpc.CallOption) (*DeadLetters, error)
+}
+
+type geofenceAdminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGeofenceAdminServiceClient(cc *grpc.ClientConn) GeofenceAdminServiceClient {
+	return &geofenceAdminServiceClient{cc}
+}
+
+func (c *geofenceAdminServiceClient) GetQuarantine(ctx context.Context, in *QuarantineRequest, opts ...grpc.CallOption) (*Quarantine, error) {
+	out := new(Quarantine)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceAdminService/GetQuarantine", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceAdminServiceClient) GetDeadLetters(ctx context.Context, in *DeadLettersRequest, opts ...grpc.CallOption) (*DeadLetters, error) {
+	out := new(DeadLetters)
+	err := c.cc.Invoke(ctx, "/geofence.GeofenceAdminService/GetDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GeofenceAdminServiceServer is the server API for GeofenceAdminService service.
+type GeofenceAdminServiceServer interface {
+	GetQuarantine(context.Context, *QuarantineRequest) (*Quarantine, error)
+	GetDeadLetters(context.Context, *DeadLettersRequest) (*DeadLetters, error)
+}
+
+// UnimplementedGeofenceAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedGeofenceAdminServiceServer struct {
+}
+
+func (*UnimplementedGeofenceAdminServiceServer) GetQuarantine(ctx context.Context, req *QuarantineRequest) (*Quarantine, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuarantine not implemented")
+}
+func (*UnimplementedGeofenceAdminServiceServer) GetDeadLetters(ctx context.Context, req *DeadLettersRequest) (*DeadLetters, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetters not implemented")
+}
+
+func RegisterGeofenceAdminServiceServer(s *grpc.Server, srv GeofenceAdminServiceServer) {
+	s.RegisterService(&_GeofenceAdminService_serviceDesc, srv)
+}
+
+func _GeofenceAdminService_GetQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuarantineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceAdminServiceServer).GetQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceAdminService/GetQuarantine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceAdminServiceServer).GetQuarantine(ctx, req.(*QuarantineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceAdminService_GetDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceAdminServiceServer).GetDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geofence.GeofenceAdminService/GetDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceAdminServiceServer).GetDeadLetters(ctx, req.(*DeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GeofenceAdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "geofence.GeofenceAdminService",
+	HandlerType: (*GeofenceAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuarantine",
+			Handler:    _GeofenceAdminService_GetQuarantine_Handler,
+		},
+		{
+			MethodName: "GetDeadLetters",
+			Handler:    _GeofenceAdminService_GetDeadLetters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "geofences.proto",